@@ -0,0 +1,447 @@
+package lists
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"github.com/feyeleanor/chain"
+)
+
+/*
+	The binary format is a compact encoding of a single term, usually a list, which preserves
+	both the sharing of sublists and lists which contain themselves.
+
+	It begins with the magic bytes "LST" and a version byte, followed by the term, and any
+	input remaining after the term is an error. Each term starts with a tag byte. Small
+	non-negative ints are folded into their tag, other integers are written as varints,
+	floating point numbers as their IEEE 754 bits and strings as a length followed by their
+	bytes. A list is written as its kind, an id, its length and then its elements. Subsequent
+	appearances of the same list are written as a reference to its id, so the decoded lists
+	share the same structure.
+
+	Values of any other type are written using encoding/gob, so their types must be registered
+	with gob.Register() to be decoded.
+*/
+
+const BINARY_VERSION = 1
+
+var binaryMagic = []byte("LST")
+
+const (
+	binaryNil byte = iota
+	binaryFalse
+	binaryTrue
+	binaryInt
+	binaryInt8
+	binaryInt16
+	binaryInt32
+	binaryInt64
+	binaryUint
+	binaryUint8
+	binaryUint16
+	binaryUint32
+	binaryUint64
+	binaryFloat32
+	binaryFloat64
+	binaryString
+	binarySymbol
+	binaryBytes
+	binaryLinearList
+	binaryCycList
+	binaryDoubleList
+	binaryDoubleLoop
+	binaryReference
+	binaryGob
+
+	binarySmallInt byte = 0x80				//	ints from 0 to 127 are written as this tag plus their value
+)
+
+//	A FormatError reports binary data which cannot be decoded
+type FormatError struct {
+	Message		string
+}
+
+func (e FormatError) Error() string {
+	return "lists: " + e.Message
+}
+
+//	A VersionError reports binary data written in a version of the format which this package cannot read
+type VersionError struct {
+	Version		int
+}
+
+func (e VersionError) Error() string {
+	return fmt.Sprintf("lists: unsupported binary format version %v", e.Version)
+}
+
+
+type binaryEncoder struct {
+	buffer		[]byte
+	ids			map[listKey]uint64
+}
+
+//	Writes a term in the binary format
+func WriteBinary(w io.Writer, v interface{}) (e error) {
+	var b []byte
+	if b, e = encodeBinary(v); e == nil {
+		_, e = w.Write(b)
+	}
+	return
+}
+
+func encodeBinary(v interface{}) (b []byte, e error) {
+	x := &binaryEncoder{ buffer: make([]byte, 0, 64), ids: make(map[listKey]uint64) }
+	x.buffer = append(append(x.buffer, binaryMagic...), BINARY_VERSION)
+	if e = x.term(v); e == nil {
+		b = x.buffer
+	}
+	return
+}
+
+func (x *binaryEncoder) tag(t byte) {
+	x.buffer = append(x.buffer, t)
+}
+
+func (x *binaryEncoder) int(t byte, i int64) {
+	x.buffer = binary.AppendVarint(append(x.buffer, t), i)
+}
+
+func (x *binaryEncoder) uint(t byte, i uint64) {
+	x.buffer = binary.AppendUvarint(append(x.buffer, t), i)
+}
+
+func (x *binaryEncoder) bytes(t byte, b []byte) {
+	x.uint(t, uint64(len(b)))
+	x.buffer = append(x.buffer, b...)
+}
+
+func (x *binaryEncoder) term(v interface{}) (e error) {
+	if h := headerOf(v); h != nil {
+		switch v.(type) {
+		case *CycList:		e = x.list(binaryCycList, h)
+		case *DoubleList:	e = x.list(binaryDoubleList, h)
+		case *DoubleLoop:	e = x.list(binaryDoubleLoop, h)
+		default:			e = x.list(binaryLinearList, h)
+		}
+		return
+	}
+
+	switch v := v.(type) {
+	case nil:				x.tag(binaryNil)
+	case bool:				if v {
+								x.tag(binaryTrue)
+							} else {
+								x.tag(binaryFalse)
+							}
+	case int:				if v > -1 && v < 0x80 {
+								x.tag(binarySmallInt | byte(v))
+							} else {
+								x.int(binaryInt, int64(v))
+							}
+	case int8:				x.int(binaryInt8, int64(v))
+	case int16:				x.int(binaryInt16, int64(v))
+	case int32:				x.int(binaryInt32, int64(v))
+	case int64:				x.int(binaryInt64, v)
+	case uint:				x.uint(binaryUint, uint64(v))
+	case uint8:				x.uint(binaryUint8, uint64(v))
+	case uint16:			x.uint(binaryUint16, uint64(v))
+	case uint32:			x.uint(binaryUint32, uint64(v))
+	case uint64:			x.uint(binaryUint64, v)
+	case float32:			x.tag(binaryFloat32)
+							x.buffer = binary.LittleEndian.AppendUint32(x.buffer, math.Float32bits(v))
+	case float64:			x.tag(binaryFloat64)
+							x.buffer = binary.LittleEndian.AppendUint64(x.buffer, math.Float64bits(v))
+	case string:			x.bytes(binaryString, []byte(v))
+	case Symbol:			x.bytes(binarySymbol, []byte(v))
+	case []byte:			x.bytes(binaryBytes, v)
+	default:				var b bytes.Buffer
+							if e = gob.NewEncoder(&b).Encode(&v); e == nil {
+								x.bytes(binaryGob, b.Bytes())
+							}
+	}
+	return
+}
+
+//	Writes a list the first time it is encountered and a reference to it thereafter.
+//	Empty lists have no identity of their own so are always written in full.
+func (x *binaryEncoder) list(t byte, l *ListHeader) (e error) {
+	k := l.key()
+	if id, ok := x.ids[k]; ok && l.length > 0 {
+		x.uint(binaryReference, id)
+		return
+	}
+	id := uint64(len(x.ids))
+	if l.length > 0 {
+		x.ids[k] = id
+	}
+	x.uint(t, id)
+	x.buffer = binary.AppendUvarint(x.buffer, uint64(l.length))
+	for v := range l.Values() {
+		if e = x.term(v); e != nil {
+			break
+		}
+	}
+	return
+}
+
+
+type binaryDecoder struct {
+	input		*bufio.Reader
+	nodeType	reflect.Type
+	lists		map[uint64]interface{}
+	target		interface{}
+}
+
+//	Reads a term written in the binary format
+func ReadBinary(r io.Reader) (interface{}, error) {
+	return decodeBinary(r, nil)
+}
+
+//	When target is a list the outermost list is decoded into it, replacing its contents, so that any
+//	references to the outermost list from within itself refer to target
+func decodeBinary(r io.Reader, target interface{}) (v interface{}, e error) {
+	x := &binaryDecoder{ input: bufio.NewReader(r), nodeType: reflect.TypeOf(&chain.Cell{}), lists: make(map[uint64]interface{}), target: target }
+	if h := headerOf(target); h != nil && h.nodeType != nil {
+		x.nodeType = h.nodeType
+	}
+	magic := make([]byte, len(binaryMagic) + 1)
+	switch _, e = io.ReadFull(x.input, magic); {
+	case e != nil:										e = x.error(e)
+	case !bytes.Equal(magic[:len(binaryMagic)], binaryMagic):	e = FormatError{ "missing binary format header" }
+	case magic[len(binaryMagic)] != BINARY_VERSION:		e = VersionError{ int(magic[len(binaryMagic)]) }
+	default:											if v, e = x.term(); e == nil {
+															if e = x.end(); e != nil {
+																v = nil
+															}
+														}
+	}
+	return
+}
+
+//	Reports any input remaining after the term as a FormatError
+func (x *binaryDecoder) end() (e error) {
+	switch _, e = x.input.ReadByte(); e {
+	case io.EOF:		e = nil
+	case nil:			e = FormatError{ "unexpected data after term" }
+	}
+	return
+}
+
+//	Reports premature end of input as a FormatError
+func (x *binaryDecoder) error(e error) error {
+	if e == io.EOF || e == io.ErrUnexpectedEOF {
+		e = FormatError{ "unexpected end of binary data" }
+	}
+	return e
+}
+
+func (x *binaryDecoder) int() (i int64, e error) {
+	if i, e = binary.ReadVarint(x.input); e != nil {
+		e = x.error(e)
+	}
+	return
+}
+
+func (x *binaryDecoder) uint() (i uint64, e error) {
+	if i, e = binary.ReadUvarint(x.input); e != nil {
+		e = x.error(e)
+	}
+	return
+}
+
+func (x *binaryDecoder) bytes() (b []byte, e error) {
+	var n uint64
+	switch n, e = x.uint(); {
+	case e != nil:
+	case n > math.MaxInt32:		e = FormatError{ fmt.Sprintf("implausible length %v", n) }
+	default:					var buffer bytes.Buffer
+								if _, e = io.CopyN(&buffer, x.input, int64(n)); e == nil {
+									b = buffer.Bytes()
+								} else {
+									e = x.error(e)
+								}
+	}
+	return
+}
+
+func (x *binaryDecoder) fixed(n int) (b []byte, e error) {
+	b = make([]byte, n)
+	if _, e = io.ReadFull(x.input, b); e != nil {
+		e = x.error(e)
+	}
+	return
+}
+
+func (x *binaryDecoder) term() (v interface{}, e error) {
+	var t byte
+	if t, e = x.input.ReadByte(); e != nil {
+		return nil, x.error(e)
+	}
+
+	var i int64
+	var u uint64
+	var b []byte
+	if t >= binarySmallInt {
+		return int(t &^ binarySmallInt), nil
+	}
+	switch t {
+	case binaryNil:
+	case binaryFalse:			v = false
+	case binaryTrue:			v = true
+	case binaryInt:				i, e = x.int()
+								v = int(i)
+	case binaryInt8:			i, e = x.int()
+								v = int8(i)
+	case binaryInt16:			i, e = x.int()
+								v = int16(i)
+	case binaryInt32:			i, e = x.int()
+								v = int32(i)
+	case binaryInt64:			v, e = x.int()
+	case binaryUint:			u, e = x.uint()
+								v = uint(u)
+	case binaryUint8:			u, e = x.uint()
+								v = uint8(u)
+	case binaryUint16:			u, e = x.uint()
+								v = uint16(u)
+	case binaryUint32:			u, e = x.uint()
+								v = uint32(u)
+	case binaryUint64:			v, e = x.uint()
+	case binaryFloat32:			if b, e = x.fixed(4); e == nil {
+									v = math.Float32frombits(binary.LittleEndian.Uint32(b))
+								}
+	case binaryFloat64:			if b, e = x.fixed(8); e == nil {
+									v = math.Float64frombits(binary.LittleEndian.Uint64(b))
+								}
+	case binaryString:			if b, e = x.bytes(); e == nil {
+									v = string(b)
+								}
+	case binarySymbol:			if b, e = x.bytes(); e == nil {
+									v = Symbol(b)
+								}
+	case binaryBytes:			v, e = x.bytes()
+	case binaryLinearList, binaryCycList, binaryDoubleList, binaryDoubleLoop:
+								v, e = x.list(t)
+	case binaryReference:		if u, e = x.uint(); e == nil {
+									var ok bool
+									if v, ok = x.lists[u]; !ok {
+										e = FormatError{ fmt.Sprintf("reference to undefined list %v", u) }
+									}
+								}
+	case binaryGob:				if b, e = x.bytes(); e == nil {
+									e = gob.NewDecoder(bytes.NewReader(b)).Decode(&v)
+								}
+	default:					e = FormatError{ fmt.Sprintf("unknown tag %v", t) }
+	}
+	return
+}
+
+//	Creates the list before reading its elements so that references to it from within itself can be resolved
+func (x *binaryDecoder) list(t byte) (v interface{}, e error) {
+	var id, n uint64
+	if id, e = x.uint(); e != nil {
+		return
+	}
+	if n, e = x.uint(); e != nil {
+		return
+	}
+
+	var h *ListHeader
+	closed := t == binaryCycList || t == binaryDoubleLoop
+	switch t {
+	case binaryLinearList:		l := &LinearList{ ListHeader{ nodeType: x.nodeType, cache: NewFingerCache() } }
+								v, h = l, &l.ListHeader
+	case binaryCycList:			c := &CycList{ ListHeader{ nodeType: x.nodeType, cache: NewFingerCache() } }
+								v, h = c, &c.ListHeader
+	case binaryDoubleList:		l := NewDoubleList()
+								v, h = l, &l.ListHeader
+	case binaryDoubleLoop:		c := NewDoubleLoop()
+								v, h = c, &c.ListHeader
+	}
+	if x.target != nil {
+		if reflect.TypeOf(x.target) != reflect.TypeOf(v) {
+			return nil, FormatError{ fmt.Sprintf("cannot decode %T into %T", v, x.target) }
+		}
+		v, h = x.target, headerOf(x.target)
+		x.target = nil
+		if h.nodeType == nil {
+			h.nodeType = x.nodeType
+		}
+		if h.cache == nil {
+			h.cache = NewFingerCache()
+		}
+		h.start, h.end, h.length = nil, nil, 0
+	}
+	if n > 0 {
+		if _, ok := x.lists[id]; ok {
+			return nil, FormatError{ fmt.Sprintf("list %v defined more than once", id) }
+		}
+		x.lists[id] = v
+	}
+
+	for ; n > 0; n-- {
+		var item interface{}
+		if item, e = x.term(); e != nil {
+			return nil, e
+		}
+		h.appendValue(item)
+	}
+	if closed {
+		h.closeLoop()
+	}
+	h.invalidate(0)
+	return
+}
+
+
+//	Encodes the list in the binary format, implementing encoding.BinaryMarshaler
+func (l *LinearList) MarshalBinary() ([]byte, error) {
+	return encodeBinary(l)
+}
+
+//	Replaces the contents of the list with those decoded from the binary format, implementing encoding.BinaryUnmarshaler
+func (l *LinearList) UnmarshalBinary(b []byte) error {
+	return unmarshalBinary(b, l)
+}
+
+func (l *LinearList) GobEncode() ([]byte, error) {
+	return l.MarshalBinary()
+}
+
+func (l *LinearList) GobDecode(b []byte) error {
+	return l.UnmarshalBinary(b)
+}
+
+//	Encodes the loop in the binary format, implementing encoding.BinaryMarshaler
+func (c *CycList) MarshalBinary() ([]byte, error) {
+	return encodeBinary(c)
+}
+
+//	Replaces the contents of the loop with those decoded from the binary format, implementing encoding.BinaryUnmarshaler
+func (c *CycList) UnmarshalBinary(b []byte) error {
+	return unmarshalBinary(b, c)
+}
+
+func (c *CycList) GobEncode() ([]byte, error) {
+	return c.MarshalBinary()
+}
+
+func (c *CycList) GobDecode(b []byte) error {
+	return c.UnmarshalBinary(b)
+}
+
+//	The contents of target are restored if decoding fails
+func unmarshalBinary(b []byte, target interface{}) (e error) {
+	var v interface{}
+	h := headerOf(target)
+	saved := *h
+	switch v, e = decodeBinary(bytes.NewReader(b), target); {
+	case e != nil:				*h = saved
+	case v != target:			e = FormatError{ fmt.Sprintf("cannot decode %T into %T", v, target) }
+	}
+	return
+}
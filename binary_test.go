@@ -0,0 +1,137 @@
+package lists
+
+import "bytes"
+import "encoding/gob"
+import "testing"
+
+type binaryPoint struct {
+	X, Y		int
+}
+
+func init() {
+	gob.Register(binaryPoint{})
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	ConfirmRoundTrip := func(v interface{}) {
+		var b bytes.Buffer
+		if e := WriteBinary(&b, v); e != nil {
+			t.Fatalf("WriteBinary(%v) failed: %v", v, e)
+		}
+		r, e := ReadBinary(&b)
+		switch x := v.(type) {
+		case Equatable:		if e != nil || !x.Equal(r) {
+								t.Fatalf("ReadBinary() should be %v but is %v: %v", v, r, e)
+							}
+		default:			if e != nil || r != v {
+								t.Fatalf("ReadBinary() should be %v but is %v: %v", v, r, e)
+							}
+		}
+	}
+	ConfirmRoundTrip(nil)
+	ConfirmRoundTrip(-7)
+	ConfirmRoundTrip("text")
+	ConfirmRoundTrip(List())
+	ConfirmRoundTrip(List(nil, true, false, 1, int8(-2), int16(3), int32(-4), int64(5), uint(6), uint8(7), uint16(8), uint32(9), uint64(10)))
+	ConfirmRoundTrip(List(float32(1.5), -2.25, "three", Symbol("four")))
+	ConfirmRoundTrip(List(binaryPoint{ 1, 2 }))
+	ConfirmRoundTrip(List(1, List(2, List(3)), List()))
+	ConfirmRoundTrip(Loop(1, Loop(2, 3)))
+	ConfirmRoundTrip(DList(1, DLoop(2, 3)))
+}
+
+func TestBinarySharing(t *testing.T) {
+	s := List(1, 2)
+	l := List(s, Loop(s), s)
+	var b bytes.Buffer
+	if e := WriteBinary(&b, l); e != nil {
+		t.Fatalf("WriteBinary(%v) failed: %v", l, e)
+	}
+	v, e := ReadBinary(&b)
+	if e != nil {
+		t.Fatalf("ReadBinary() failed: %v", e)
+	}
+	r := v.(*LinearList)
+	switch {
+	case !r.Equal(l):										t.Fatalf("ReadBinary() should be %v but is %v", l, r)
+	case r.At(0) != r.At(2):								t.Fatalf("ReadBinary() should share %v", r.At(0))
+	case r.At(1).(*CycList).At(0) != r.At(0):				t.Fatalf("ReadBinary() should share %v", r.At(0))
+	case r.String() != l.String():							t.Fatalf("ReadBinary() should print as %v not %v", l, r)
+	}
+
+	c := Loop(0)
+	c.Append(List(c))
+	b.Reset()
+	WriteBinary(&b, c)
+	if v, e := ReadBinary(&b); e != nil {
+		t.Fatalf("ReadBinary() failed: %v", e)
+	} else if x := v.(*CycList); x.At(1).(*LinearList).At(0) != x || x.Validate() != nil {
+		t.Fatalf("ReadBinary() should contain itself: %v", x)
+	}
+}
+
+func TestBinaryCompactness(t *testing.T) {
+	l := List()
+	for i := 0; i < 1000; i++ {
+		l.Append(i % 100)
+		l.Append(float64(i) / 3)
+	}
+	b, _ := l.MarshalBinary()
+	if t1 := len(l.String()); len(b) > t1 * 2 / 3 {
+		t.Fatalf("binary encoding of %v bytes should be much smaller than %v bytes of text", len(b), t1)
+	}
+}
+
+func TestGob(t *testing.T) {
+	type record struct {
+		Name		string
+		Items		*LinearList
+		Ring		*CycList
+	}
+	l := List(1, "a")
+	l.Append(l)
+	in := record{ "r", l, Loop(2, List(3)) }
+
+	var b bytes.Buffer
+	if e := gob.NewEncoder(&b).Encode(in); e != nil {
+		t.Fatalf("gob encoding failed: %v", e)
+	}
+	var out record
+	if e := gob.NewDecoder(&b).Decode(&out); e != nil {
+		t.Fatalf("gob decoding failed: %v", e)
+	}
+	switch {
+	case out.Name != "r":									t.Fatalf("Name should be r not %v", out.Name)
+	case !out.Items.Equal(in.Items):						t.Fatalf("Items should be %v but is %v", in.Items, out.Items)
+	case out.Items.At(2) != out.Items:						t.Fatalf("Items should contain itself")
+	case !out.Ring.Equal(in.Ring):							t.Fatalf("Ring should be %v but is %v", in.Ring, out.Ring)
+	}
+}
+
+func TestBinaryErrors(t *testing.T) {
+	ConfirmError := func(b []byte, x error) {
+		if _, e := ReadBinary(bytes.NewReader(b)); e != x {
+			t.Fatalf("ReadBinary(%v) should fail with %v not %v", b, x, e)
+		}
+	}
+	ConfirmError([]byte("LS"), FormatError{ "unexpected end of binary data" })
+	ConfirmError([]byte("XYZ\x01\x00"), FormatError{ "missing binary format header" })
+	ConfirmError([]byte("LST\x09\x00"), VersionError{ 9 })
+	ConfirmError([]byte("LST\x01\x7f"), FormatError{ "unknown tag 127" })
+	ConfirmError([]byte{ 'L', 'S', 'T', 1, binaryLinearList, 0, 2, binaryInt }, FormatError{ "unexpected end of binary data" })
+	ConfirmError([]byte{ 'L', 'S', 'T', 1, binaryReference, 4 }, FormatError{ "reference to undefined list 4" })
+	ConfirmError([]byte{ 'L', 'S', 'T', 1, binaryNil, binaryNil }, FormatError{ "unexpected data after term" })
+
+	b, _ := Loop(1).MarshalBinary()
+	if e := List().UnmarshalBinary(b); e == nil {
+		t.Fatalf("UnmarshalBinary() of a loop into a LinearList should fail")
+	}
+	l := List(9)
+	if e := l.UnmarshalBinary(b[:len(b) - 1]); e == nil || !l.Equal(List(9)) {
+		t.Fatalf("UnmarshalBinary() of truncated data should fail without altering the list: %v", l)
+	}
+	b, _ = List(1).MarshalBinary()
+	if e := l.UnmarshalBinary(append(b, 0)); e == nil || !l.Equal(List(9)) {
+		t.Fatalf("UnmarshalBinary() of data with trailing bytes should fail without altering the list: %v", l)
+	}
+}
@@ -2,24 +2,204 @@ package lists
 
 import "github.com/feyeleanor/chain"
 
-type cachedNode struct {
-	chain.Node
-	index	int
+/*
+	A PositionCache remembers the location of nodes in a list so that positional lookups can
+	start from a nearby node rather than from the start of the list.
+
+	Closest() returns the cached node with the highest index not greater than i, or nil if
+	there is none. As the list is walked towards the target each node passed is reported to
+	Passed() and the node found is reported to Found(). Whenever the list is modified all
+	entries at or after the first affected position are discarded by Invalidate().
+*/
+type PositionCache interface {
+	Closest(i int) (node chain.Node, offset int)
+	Passed(i int, node chain.Node)
+	Found(i int, node chain.Node)
+	Invalidate(from int)
+	Stats() CacheStats
+}
+
+//	CacheStats records how many lookups a PositionCache was able to shorten
+type CacheStats struct {
+	Hits		int
+	Misses		int
+}
+
+func (s CacheStats) Stats() CacheStats {
+	return s
+}
+
+func (s *CacheStats) record(hit bool) {
+	if hit {
+		s.Hits++
+	} else {
+		s.Misses++
+	}
+}
+
+
+//	A FingerCache remembers the most recently located node.
+type FingerCache struct {
+	CacheStats
+	node		chain.Node
+	index		int
+}
+
+func NewFingerCache() *FingerCache {
+	return &FingerCache{}
+}
+
+func (c *FingerCache) Closest(i int) (node chain.Node, offset int) {
+	if c.node != nil && c.index <= i {
+		node, offset = c.node, c.index
+	}
+	c.record(node != nil)
+	return
 }
 
-func (c cachedNode) Update(i int, node chain.Node) {
+func (c *FingerCache) Passed(i int, node chain.Node) {}
+
+func (c *FingerCache) Found(i int, node chain.Node) {
 	c.index = i
-	c.Node = node
+	c.node = node
 }
 
-func (c cachedNode) Clear() {
-	c.index = 0
-	c.Node = nil
+func (c *FingerCache) Invalidate(from int) {
+	if c.index >= from {
+		c.index = 0
+		c.node = nil
+	}
+}
+
+
+type finger struct {
+	node		chain.Node
+	index		int
 }
 
-func (c cachedNode) ClosestNode(i int) (node chain.Node, offset int) {
-	if i > c.index {
-		return c.Node, c.index
+//	A FingersCache remembers a fixed number of recently located nodes, replacing the oldest when full.
+type FingersCache struct {
+	CacheStats
+	fingers		[]finger
+	next		int
+}
+
+func NewFingersCache(n int) *FingersCache {
+	if n < 1 {
+		n = 1
 	}
+	return &FingersCache{ fingers: make([]finger, 0, n) }
+}
+
+func (c *FingersCache) Closest(i int) (node chain.Node, offset int) {
+	for _, f := range c.fingers {
+		if f.index <= i && (node == nil || f.index > offset) {
+			node, offset = f.node, f.index
+		}
+	}
+	c.record(node != nil)
 	return
-}
\ No newline at end of file
+}
+
+func (c *FingersCache) Passed(i int, node chain.Node) {}
+
+func (c *FingersCache) Found(i int, node chain.Node) {
+	for _, f := range c.fingers {
+		if f.index == i {
+			return
+		}
+	}
+	if len(c.fingers) < cap(c.fingers) {
+		c.fingers = append(c.fingers, finger{ node, i })
+	} else {
+		c.fingers[c.next] = finger{ node, i }
+		c.next = (c.next + 1) % len(c.fingers)
+	}
+}
+
+func (c *FingersCache) Invalidate(from int) {
+	fingers := c.fingers[:0]
+	for _, f := range c.fingers {
+		if f.index < from {
+			fingers = append(fingers, f)
+		}
+	}
+	c.fingers = fingers
+	c.next = 0
+}
+
+
+//	A SkipCache builds a sparse index holding every k-th node of the list as it is walked.
+type SkipCache struct {
+	CacheStats
+	stride		int
+	nodes		[]chain.Node
+}
+
+func NewSkipCache(k int) *SkipCache {
+	if k < 1 {
+		k = 1
+	}
+	return &SkipCache{ stride: k }
+}
+
+func (c *SkipCache) Closest(i int) (node chain.Node, offset int) {
+	j := i / c.stride - 1
+	if j >= len(c.nodes) {
+		j = len(c.nodes) - 1
+	}
+	if j > -1 {
+		node, offset = c.nodes[j], (j + 1) * c.stride
+	}
+	c.record(node != nil)
+	return
+}
+
+func (c *SkipCache) Passed(i int, node chain.Node) {
+	if i > 0 && i % c.stride == 0 && i / c.stride == len(c.nodes) + 1 {
+		c.nodes = append(c.nodes, node)
+	}
+}
+
+func (c *SkipCache) Found(i int, node chain.Node) {
+	c.Passed(i, node)
+}
+
+func (c *SkipCache) Invalidate(from int) {
+	if from < 1 {
+		c.nodes = c.nodes[:0]
+	} else if n := (from - 1) / c.stride; n < len(c.nodes) {
+		c.nodes = c.nodes[:n]
+	}
+}
+
+
+//	Selects the strategy used to cache node positions in the list, or disables caching if c is nil.
+func (l *ListHeader) UsePositionCache(c PositionCache) {
+	l.cache = c
+}
+
+//	Reports how many positional lookups were shortened by the list's PositionCache
+func (l ListHeader) PositionStats() (s CacheStats) {
+	if l.cache != nil {
+		s = l.cache.Stats()
+	}
+	return
+}
+
+//	Discards cached positions and remembered predecessors at or after the given index.
+//	This is called once a mutation is complete, so the modification is counted and in debug mode the list is also validated.
+func (l *ListHeader) invalidate(i int) {
+	l.forgetPredecessors(i)
+	l.modified(i)
+}
+
+//	Completes a mutation as invalidate does but leaves the remembered predecessors to the caller,
+//	which allows operations at the start of a list to shift them rather than discard them.
+func (l *ListHeader) modified(i int) {
+	l.modifications++
+	if l.cache != nil {
+		l.cache.Invalidate(i)
+	}
+	l.check()
+}
@@ -0,0 +1,96 @@
+package lists
+
+import "testing"
+
+func TestPositionCaches(t *testing.T) {
+	ConfirmCache := func(name string, c PositionCache) {
+		l := List()
+		l.UsePositionCache(c)
+		for i := 0; i < 50; i++ {
+			l.Append(i)
+		}
+		ConfirmAt := func(i int, v interface{}) {
+			if x := l.At(i); x != v {
+				t.Fatalf("%v: %v.At(%v) should be %v but is %v", name, l, i, v, x)
+			}
+		}
+
+		for i := 0; i < 50; i++ {
+			ConfirmAt(i, i)
+		}
+		for i := 49; i > -1; i -= 7 {
+			ConfirmAt(i, i)
+		}
+		if s := l.PositionStats(); c != nil && s.Hits == 0 {
+			t.Fatalf("%v: sequential access should hit the cache: %+v", name, s)
+		}
+
+		l.Insert(10, -1)
+		ConfirmAt(10, -1)
+		ConfirmAt(11, 10)
+		ConfirmAt(30, 29)
+
+		l.Delete(5, 9)
+		ConfirmAt(5, -1)
+		ConfirmAt(25, 29)
+
+		x := l.Cut(20, 24)
+		ConfirmAt(20, 29)
+		if x.At(2) != 26 {
+			t.Fatalf("%v: %v.At(2) should be 26 but is %v", name, x, x.At(2))
+		}
+
+		l.Absorb(20, &x)
+		ConfirmAt(22, 26)
+		ConfirmAt(25, 29)
+
+		l.Reverse()
+		ConfirmAt(l.Len() - 1, 0)
+		ConfirmAt(l.Len() - 6, -1)
+
+		l.Reverse()
+		l.Set(12, List(100, 101))
+		ConfirmAt(13, 17)
+		l.Flatten()
+		ConfirmAt(12, 100)
+		ConfirmAt(14, 17)
+
+		l.Tail()
+		ConfirmAt(0, 1)
+		ConfirmAt(13, 17)
+	}
+	ConfirmCache("FingerCache", NewFingerCache())
+	ConfirmCache("FingersCache", NewFingersCache(4))
+	ConfirmCache("SkipCache", NewSkipCache(5))
+	ConfirmCache("no cache", nil)
+}
+
+func TestPositionCacheRotate(t *testing.T) {
+	c := Loop(0, 1, 2, 3, 4, 5, 6, 7)
+	c.UsePositionCache(NewSkipCache(2))
+	for i := 0; i < c.Len(); i++ {
+		c.At(i)
+	}
+	c.Rotate(3)
+	for i := 0; i < c.Len(); i++ {
+		if x := c.At(i); x != (i + 3) % c.Len() {
+			t.Fatalf("%v.At(%v) should be %v but is %v", c, i, (i + 3) % c.Len(), x)
+		}
+	}
+}
+
+func TestPositionStats(t *testing.T) {
+	l := List(0, 1, 2, 3, 4, 5)
+	l.UsePositionCache(NewFingerCache())
+	l.At(3)
+	l.At(4)
+	l.At(2)
+	if s := l.PositionStats(); s.Hits != 1 || s.Misses != 2 {
+		t.Fatalf("stats should be 1 hit and 2 misses not %+v", s)
+	}
+
+	l.UsePositionCache(nil)
+	if s := l.PositionStats(); s.Hits != 0 || s.Misses != 0 {
+		t.Fatalf("stats without a cache should be empty not %+v", s)
+	}
+}
@@ -0,0 +1,313 @@
+package lists
+
+import "fmt"
+
+/*
+	The checked variants of the positional operations behave exactly as the operations they
+	are named after, except that an index or range which the list cannot honour is reported
+	as an error instead of being ignored or clamped. This distinguishes a nil stored in a list
+	from a position which does not exist.
+*/
+
+//	An IndexOutOfRange reports an index which does not refer to a position in a list of length Len
+type IndexOutOfRange struct {
+	Index		int
+	Len			int
+}
+
+func (e IndexOutOfRange) Error() string {
+	return fmt.Sprintf("index %v out of range for list of length %v", e.Index, e.Len)
+}
+
+//	An InvalidRange reports a range whose start falls after its end
+type InvalidRange struct {
+	From		int
+	To			int
+}
+
+func (e InvalidRange) Error() string {
+	return fmt.Sprintf("invalid range %v to %v", e.From, e.To)
+}
+
+//	Reports whether the inclusive range start to end lies within the list.
+//	The ranges accepted are exactly those which EnforceBounds leaves unaltered, so rather than being clamped
+//	a range which overhangs either end of the list is an error. When EnforceBounds rejects the range after
+//	clamping its end, the start lies beyond the end of the list.
+func (l ListHeader) CheckBounds(start, end int) (e error) {
+	from, to := start, end
+	switch ok := l.EnforceBounds(&from, &to); {
+	case start > end:					e = InvalidRange{ start, end }
+	case from != start || !ok:			e = IndexOutOfRange{ start, l.length }
+	case to != end:						e = IndexOutOfRange{ end, l.length }
+	}
+	return
+}
+
+//	Reports whether i refers to an element of the list
+func (l ListHeader) checkIndex(i int) error {
+	return l.CheckBounds(i, i)
+}
+
+//	Reports whether i is a position at which an element can be inserted, which includes the end of the list
+func (l ListHeader) checkInsertion(i int) (e error) {
+	if i < 0 || i > l.length {
+		e = IndexOutOfRange{ i, l.length }
+	}
+	return
+}
+
+//	Reports whether the loop has an element for i to wrap onto
+func (l ListHeader) checkLoop(i int) (e error) {
+	if l.length == 0 {
+		e = IndexOutOfRange{ i, 0 }
+	}
+	return
+}
+
+//	Reports whether i is a position at which an element can be inserted into the loop, which an empty loop has only at 0
+func (l ListHeader) checkLoopInsertion(i int) (e error) {
+	if l.length == 0 && i != 0 {
+		e = IndexOutOfRange{ i, 0 }
+	}
+	return
+}
+
+
+//	Returns the value stored at the given offset from the start of the list, or an error if there is no such element
+func (l ListHeader) AtE(i int) (r interface{}, e error) {
+	if e = l.checkIndex(i); e == nil {
+		r = l.At(i)
+	}
+	return
+}
+
+//	Sets the value stored at the given offset from the start of the list, or returns an error if there is no such element
+func (l ListHeader) SetE(i int, v interface{}) (e error) {
+	if e = l.checkIndex(i); e == nil {
+		l.Set(i, v)
+	}
+	return
+}
+
+
+//	Inserts an item into the list at the given location, which may be the end of the list
+func (l *LinearList) InsertE(i int, o interface{}) (e error) {
+	if e = l.checkInsertion(i); e == nil {
+		l.Insert(i, o)
+	}
+	return
+}
+
+//	Removes all elements in the inclusive range from the list
+func (l *LinearList) DeleteE(from, to int) (e error) {
+	if e = l.CheckBounds(from, to); e == nil {
+		l.Delete(from, to)
+	}
+	return
+}
+
+//	Removes the elements in the inclusive range from the list and returns a new list containing them
+func (l *LinearList) CutE(from, to int) (r LinearList, e error) {
+	if e = l.CheckBounds(from, to); e == nil {
+		r = l.Cut(from, to)
+	} else {
+		r.nodeType = l.nodeType
+	}
+	return
+}
+
+//	Inserts all the elements of another list at the given location, destroying the other list if successful.
+//	Absorbing a nil list leaves the list unchanged.
+func (l *LinearList) AbsorbE(i int, o *LinearList) (e error) {
+	if e = l.checkInsertion(i); e == nil && o != nil {
+		l.Absorb(i, o)
+	}
+	return
+}
+
+
+//	Returns the value stored at the given offset from the start of the loop, or an error if the loop is empty
+func (c CycList) AtE(i int) (r interface{}, e error) {
+	if e = c.checkLoop(i); e == nil {
+		r = c.At(i)
+	}
+	return
+}
+
+//	Sets the value stored at the given offset from the start of the loop, or returns an error if the loop is empty
+func (c CycList) SetE(i int, v interface{}) (e error) {
+	if e = c.checkLoop(i); e == nil {
+		c.Set(i, v)
+	}
+	return
+}
+
+//	Inserts an item into the loop at the given offset, or returns an error if the loop is empty and the offset is not 0
+func (c *CycList) InsertE(i int, o interface{}) (e error) {
+	if e = c.checkLoopInsertion(i); e == nil {
+		c.Insert(i, o)
+	}
+	return
+}
+
+//	Removes the elements in the range from the loop, or returns an error if the loop is empty
+func (c *CycList) DeleteE(from, to int) (e error) {
+	if e = c.checkLoop(from); e == nil {
+		c.Delete(from, to)
+	}
+	return
+}
+
+//	Removes the elements in the range from the loop and returns a new loop containing them, or returns an error if the loop is empty
+func (c *CycList) CutE(from, to int) (r CycList, e error) {
+	if e = c.checkLoop(from); e == nil {
+		r = c.Cut(from, to)
+	} else {
+		r.nodeType = c.nodeType
+	}
+	return
+}
+
+//	Inserts all the elements of another loop at the given offset, destroying the other loop if successful, or returns an
+//	error if the loop is empty and the offset is not 0. Absorbing a nil loop leaves the loop unchanged.
+func (c *CycList) AbsorbE(i int, o *CycList) (e error) {
+	if e = c.checkLoopInsertion(i); e == nil && o != nil {
+		c.Absorb(i, o)
+	}
+	return
+}
+
+
+//	Inserts an item into the list at the given location, which may be the end of the list
+func (l *DoubleList) InsertE(i int, o interface{}) (e error) {
+	if e = l.checkInsertion(i); e == nil {
+		l.Insert(i, o)
+	}
+	return
+}
+
+//	Removes all elements in the inclusive range from the list
+func (l *DoubleList) DeleteE(from, to int) (e error) {
+	if e = l.CheckBounds(from, to); e == nil {
+		l.Delete(from, to)
+	}
+	return
+}
+
+//	Returns the value stored at the given offset from the start of the list, or an error if there is no such element
+func (l DoubleList) AtE(i int) (r interface{}, e error) {
+	if e = l.checkIndex(i); e == nil {
+		r = l.At(i)
+	}
+	return
+}
+
+//	Sets the value stored at the given offset from the start of the list, or returns an error if there is no such element
+func (l DoubleList) SetE(i int, v interface{}) (e error) {
+	if e = l.checkIndex(i); e == nil {
+		l.Set(i, v)
+	}
+	return
+}
+
+
+//	Returns the value stored at the given offset from the start of the loop, or an error if the loop is empty
+func (c DoubleLoop) AtE(i int) (r interface{}, e error) {
+	if e = c.checkLoop(i); e == nil {
+		r = c.At(i)
+	}
+	return
+}
+
+//	Sets the value stored at the given offset from the start of the loop, or returns an error if the loop is empty
+func (c DoubleLoop) SetE(i int, v interface{}) (e error) {
+	if e = c.checkLoop(i); e == nil {
+		c.Set(i, v)
+	}
+	return
+}
+
+
+//	Returns the value stored at the given offset from the start of the list, or an error if there is no such element
+//	or it does not hold a value of the list's type
+func (l TypedHeader[T]) AtE(i int) (r T, e error) {
+	var v interface{}
+	if v, e = l.header.AtE(i); e == nil {
+		r, e = typedE[T](v)
+	}
+	return
+}
+
+//	Sets the value stored at the given offset from the start of the list, or returns an error if there is no such element
+func (l TypedHeader[T]) SetE(i int, v T) error {
+	return l.header.SetE(i, v)
+}
+
+//	Returns the value stored at the given offset from the start of the loop, or an error if the loop is empty
+//	or the element does not hold a value of the loop's type
+func (c TypedLoop[T]) AtE(i int) (r T, e error) {
+	var v interface{}
+	if v, e = c.loop.AtE(i); e == nil {
+		r, e = typedE[T](v)
+	}
+	return
+}
+
+//	Sets the value stored at the given offset from the start of the loop, or returns an error if the loop is empty
+func (c TypedLoop[T]) SetE(i int, v T) error {
+	return c.loop.SetE(i, v)
+}
+
+//	Inserts an item into the list at the given location, which may be the end of the list
+func (l *TypedList[T]) InsertE(i int, v T) error {
+	return l.list.InsertE(i, v)
+}
+
+//	Removes all elements in the inclusive range from the list
+func (l *TypedList[T]) DeleteE(from, to int) error {
+	return l.list.DeleteE(from, to)
+}
+
+//	Removes the elements in the inclusive range from the list and returns a new list containing them
+func (l *TypedList[T]) CutE(from, to int) (*TypedList[T], error) {
+	r, e := l.list.CutE(from, to)
+	return typedList[T](&r), e
+}
+
+//	Inserts all the elements of another list at the given location, destroying the other list if successful.
+//	Absorbing a nil list leaves the list unchanged.
+func (l *TypedList[T]) AbsorbE(i int, o *TypedList[T]) (e error) {
+	if o == nil {
+		e = l.list.AbsorbE(i, nil)
+	} else {
+		e = l.list.AbsorbE(i, o.list)
+	}
+	return
+}
+
+//	Inserts an item into the loop at the given location, which must be 0 for an empty loop
+func (c *TypedLoop[T]) InsertE(i int, v T) error {
+	return c.loop.InsertE(i, v)
+}
+
+//	Removes all elements in the inclusive range from the loop
+func (c *TypedLoop[T]) DeleteE(from, to int) error {
+	return c.loop.DeleteE(from, to)
+}
+
+//	Removes the elements in the inclusive range from the loop and returns a new loop containing them
+func (c *TypedLoop[T]) CutE(from, to int) (*TypedLoop[T], error) {
+	r, e := c.loop.CutE(from, to)
+	return typedLoop[T](&r), e
+}
+
+//	Inserts all the elements of another loop at the given location, destroying the other loop if successful.
+//	Absorbing a nil loop leaves the loop unchanged.
+func (c *TypedLoop[T]) AbsorbE(i int, o *TypedLoop[T]) (e error) {
+	if o == nil {
+		e = c.loop.AbsorbE(i, nil)
+	} else {
+		e = c.loop.AbsorbE(i, o.loop)
+	}
+	return
+}
@@ -0,0 +1,223 @@
+package lists
+
+import "testing"
+
+func TestCheckBounds(t *testing.T) {
+	ConfirmBounds := func(l *LinearList, from, to int, x error) {
+		if e := l.CheckBounds(from, to); e != x {
+			t.Fatalf("%v.CheckBounds(%v, %v) should be %v not %v", l, from, to, x, e)
+		}
+	}
+	ConfirmBounds(List(), 0, 0, IndexOutOfRange{ 0, 0 })
+	ConfirmBounds(List(0), 0, 0, nil)
+	ConfirmBounds(List(0, 1, 2), 0, 2, nil)
+	ConfirmBounds(List(0, 1, 2), 1, 1, nil)
+	ConfirmBounds(List(0, 1, 2), -1, 1, IndexOutOfRange{ -1, 3 })
+	ConfirmBounds(List(0, 1, 2), 1, 3, IndexOutOfRange{ 3, 3 })
+	ConfirmBounds(List(0, 1, 2), 4, 5, IndexOutOfRange{ 4, 3 })
+	ConfirmBounds(List(0, 1, 2), 2, 1, InvalidRange{ 2, 1 })
+	ConfirmBounds(List(0, 1, 2), 3, 3, IndexOutOfRange{ 3, 3 })
+	ConfirmBounds(List(0, 1, 2), -2, -1, IndexOutOfRange{ -2, 3 })
+
+	for n := 0; n < 4; n++ {
+		l := List()
+		l.Expand(0, n)
+		for from := -2; from < n + 2; from++ {
+			for to := -2; to < n + 2; to++ {
+				s, e := from, to
+				if ok := l.EnforceBounds(&s, &e) && s == from && e == to; ok != (l.CheckBounds(from, to) == nil) {
+					t.Fatalf("%v.CheckBounds(%v, %v) should agree with EnforceBounds", l, from, to)
+				}
+			}
+		}
+	}
+}
+
+func TestAtE(t *testing.T) {
+	l := List(0, nil, 2)
+	switch v, e := l.AtE(1); {
+	case e != nil:			t.Fatalf("AtE(1) should succeed: %v", e)
+	case v != nil:			t.Fatalf("AtE(1) should be nil not %v", v)
+	}
+	if _, e := l.AtE(3); e != (IndexOutOfRange{ 3, 3 }) {
+		t.Fatalf("AtE(3) should fail not %v", e)
+	}
+	if e := l.SetE(-1, 1); e != (IndexOutOfRange{ -1, 3 }) {
+		t.Fatalf("SetE(-1) should fail not %v", e)
+	}
+	if e := l.SetE(1, 1); e != nil || l.At(1) != 1 {
+		t.Fatalf("SetE(1) should set 1 in %v: %v", l, e)
+	}
+
+	c := Loop(0, 1, 2)
+	if v, e := c.AtE(-1); e != nil || v != 2 {
+		t.Fatalf("AtE(-1) should be 2 not %v: %v", v, e)
+	}
+	if _, e := Loop().AtE(0); e != (IndexOutOfRange{ 0, 0 }) {
+		t.Fatalf("AtE(0) of an empty loop should fail not %v", e)
+	}
+	if e := DLoop().SetE(1, 0); e != (IndexOutOfRange{ 1, 0 }) {
+		t.Fatalf("SetE(1) of an empty loop should fail not %v", e)
+	}
+	if _, e := DList(0).AtE(1); e != (IndexOutOfRange{ 1, 1 }) {
+		t.Fatalf("AtE(1) should fail not %v", e)
+	}
+
+	s := ListOf("a", "b")
+	if v, e := s.AtE(1); e != nil || v != "b" {
+		t.Fatalf("AtE(1) should be b not %v: %v", v, e)
+	}
+	if _, e := s.AtE(2); e == nil {
+		t.Fatalf("AtE(2) should fail")
+	}
+	s.Untyped().Set(0, 1)
+	if _, e := s.AtE(0); e == nil {
+		t.Fatalf("AtE(0) of a mistyped element should fail")
+	} else if _, ok := e.(TypeMismatch); !ok {
+		t.Fatalf("AtE(0) of a mistyped element should be a TypeMismatch not %v", e)
+	}
+}
+
+func TestInsertE(t *testing.T) {
+	ConfirmInsert := func(l *LinearList, i int, v interface{}, x error, r *LinearList) {
+		switch e := l.InsertE(i, v); {
+		case e != x:			t.Fatalf("InsertE(%v, %v) should be %v not %v", i, v, x, e)
+		case !l.Equal(r):		t.Fatalf("InsertE(%v, %v) should be %v but is %v", i, v, r, l)
+		}
+	}
+	ConfirmInsert(List(), 0, 0, nil, List(0))
+	ConfirmInsert(List(), 1, 0, IndexOutOfRange{ 1, 0 }, List())
+	ConfirmInsert(List(0, 2), 1, 1, nil, List(0, 1, 2))
+	ConfirmInsert(List(0, 1), 2, 2, nil, List(0, 1, 2))
+	ConfirmInsert(List(0, 1), 3, 2, IndexOutOfRange{ 3, 2 }, List(0, 1))
+	ConfirmInsert(List(0, 1), -1, 2, IndexOutOfRange{ -1, 2 }, List(0, 1))
+
+	l := DList(0, 2)
+	if e := l.InsertE(1, 1); e != nil || !l.Equal(DList(0, 1, 2)) {
+		t.Fatalf("InsertE(1, 1) should be %v but is %v: %v", DList(0, 1, 2), l, e)
+	}
+	if e := l.InsertE(4, 1); e != (IndexOutOfRange{ 4, 3 }) {
+		t.Fatalf("InsertE(4, 1) should fail not %v", e)
+	}
+}
+
+func TestDeleteE(t *testing.T) {
+	ConfirmDelete := func(l *LinearList, from, to int, x error, r *LinearList) {
+		switch e := l.DeleteE(from, to); {
+		case e != x:			t.Fatalf("DeleteE(%v, %v) should be %v not %v", from, to, x, e)
+		case !l.Equal(r):		t.Fatalf("DeleteE(%v, %v) should be %v but is %v", from, to, r, l)
+		}
+	}
+	ConfirmDelete(List(), 0, 0, IndexOutOfRange{ 0, 0 }, List())
+	ConfirmDelete(List(0, 1, 2), 1, 1, nil, List(0, 2))
+	ConfirmDelete(List(0, 1, 2), 0, 2, nil, List())
+	ConfirmDelete(List(0, 1, 2), 1, 3, IndexOutOfRange{ 3, 3 }, List(0, 1, 2))
+	ConfirmDelete(List(0, 1, 2), 2, 1, InvalidRange{ 2, 1 }, List(0, 1, 2))
+
+	l := DList(0, 1, 2)
+	if e := l.DeleteE(-1, 0); e != (IndexOutOfRange{ -1, 3 }) || l.Len() != 3 {
+		t.Fatalf("DeleteE(-1, 0) should fail not %v", e)
+	}
+}
+
+func TestCutE(t *testing.T) {
+	l := List(0, 1, 2, 3)
+	switch r, e := l.CutE(1, 2); {
+	case e != nil:						t.Fatalf("CutE(1, 2) should succeed: %v", e)
+	case !r.Equal(List(1, 2)):			t.Fatalf("CutE(1, 2) should be %v but is %v", List(1, 2), r)
+	case !l.Equal(List(0, 3)):			t.Fatalf("CutE(1, 2) should leave %v but leaves %v", List(0, 3), l)
+	}
+	switch r, e := l.CutE(1, 2); {
+	case e != (IndexOutOfRange{ 2, 2 }):	t.Fatalf("CutE(1, 2) should fail not %v", e)
+	case r.Len() != 0:					t.Fatalf("CutE(1, 2) should be empty not %v", r)
+	case !l.Equal(List(0, 3)):			t.Fatalf("CutE(1, 2) should not alter %v", l)
+	}
+}
+
+func TestAbsorbE(t *testing.T) {
+	l := List(0, 3)
+	if e := l.AbsorbE(1, List(1, 2)); e != nil || !l.Equal(List(0, 1, 2, 3)) {
+		t.Fatalf("AbsorbE(1) should be %v but is %v: %v", List(0, 1, 2, 3), l, e)
+	}
+	o := List(4)
+	switch e := l.AbsorbE(5, o); {
+	case e != (IndexOutOfRange{ 5, 4 }):	t.Fatalf("AbsorbE(5) should fail not %v", e)
+	case !o.Equal(List(4)):				t.Fatalf("AbsorbE(5) should not destroy %v", o)
+	}
+	if e := l.AbsorbE(0, nil); e != nil || l.Len() != 4 {
+		t.Fatalf("AbsorbE(0, nil) should leave %v unchanged: %v", l, e)
+	}
+}
+
+func TestCycListE(t *testing.T) {
+	c := Loop(0, 2)
+	switch {
+	case c.InsertE(1, 1) != nil:				t.Fatalf("InsertE(1) should succeed")
+	case c.InsertE(-1, 3) != nil:				t.Fatalf("InsertE(-1) should wrap onto the loop")
+	case !c.Equal(Loop(0, 1, 3, 2)):			t.Fatalf("InsertE() should give %v not %v", Loop(0, 1, 3, 2), c)
+	}
+	if e := Loop().InsertE(1, 0); e != (IndexOutOfRange{ 1, 0 }) {
+		t.Fatalf("InsertE(1) of an empty loop should fail not %v", e)
+	}
+	if x := Loop(); x.InsertE(0, 0) != nil || !x.Equal(Loop(0)) {
+		t.Fatalf("InsertE(0) of an empty loop should give %v not %v", Loop(0), x)
+	}
+
+	if e := c.DeleteE(-1, -1); e != nil || !c.Equal(Loop(0, 1, 3)) {
+		t.Fatalf("DeleteE(-1, -1) should give %v not %v: %v", Loop(0, 1, 3), c, e)
+	}
+	if e := Loop().DeleteE(0, 0); e != (IndexOutOfRange{ 0, 0 }) {
+		t.Fatalf("DeleteE(0, 0) of an empty loop should fail not %v", e)
+	}
+
+	switch r, e := c.CutE(2, 0); {
+	case e != nil:								t.Fatalf("CutE(2, 0) should succeed: %v", e)
+	case !r.Equal(Loop(3, 0)):					t.Fatalf("CutE(2, 0) should be %v not %v", Loop(3, 0), r)
+	case !c.Equal(Loop(1)):						t.Fatalf("CutE(2, 0) should leave %v not %v", Loop(1), c)
+	}
+	if r, e := Loop().CutE(0, 1); e != (IndexOutOfRange{ 0, 0 }) || r.Len() != 0 {
+		t.Fatalf("CutE(0, 1) of an empty loop should fail not %v, %v", r, e)
+	}
+
+	if e := c.AbsorbE(1, Loop(2, 3)); e != nil || !c.Equal(Loop(1, 2, 3)) {
+		t.Fatalf("AbsorbE(1) should give %v not %v: %v", Loop(1, 2, 3), c, e)
+	}
+	o := Loop(4)
+	switch e := Loop().AbsorbE(2, o); {
+	case e != (IndexOutOfRange{ 2, 0 }):		t.Fatalf("AbsorbE(2) of an empty loop should fail not %v", e)
+	case !o.Equal(Loop(4)):						t.Fatalf("AbsorbE(2) should not destroy %v", o)
+	}
+	if e := c.AbsorbE(0, nil); e != nil || c.Len() != 3 {
+		t.Fatalf("AbsorbE(0, nil) should leave %v unchanged: %v", c, e)
+	}
+}
+
+func TestTypedListE(t *testing.T) {
+	l := ListOf(0, 3)
+	if e := l.InsertE(1, 1); e != nil || !l.Equal(ListOf(0, 1, 3)) {
+		t.Fatalf("InsertE(1, 1) should be %v but is %v: %v", ListOf(0, 1, 3), l, e)
+	}
+	if e := l.InsertE(4, 1); e != (IndexOutOfRange{ 4, 3 }) {
+		t.Fatalf("InsertE(4, 1) should fail not %v", e)
+	}
+	if e := l.AbsorbE(2, ListOf(2)); e != nil || !l.Equal(ListOf(0, 1, 2, 3)) {
+		t.Fatalf("AbsorbE(2) should be %v but is %v: %v", ListOf(0, 1, 2, 3), l, e)
+	}
+	if e := l.AbsorbE(0, nil); e != nil || l.Len() != 4 {
+		t.Fatalf("AbsorbE(0, nil) should leave %v unchanged: %v", l, e)
+	}
+	switch r, e := l.CutE(1, 2); {
+	case e != nil:						t.Fatalf("CutE(1, 2) should succeed: %v", e)
+	case !r.Equal(ListOf(1, 2)):		t.Fatalf("CutE(1, 2) should be %v but is %v", ListOf(1, 2), r)
+	case !l.Equal(ListOf(0, 3)):		t.Fatalf("CutE(1, 2) should leave %v but leaves %v", ListOf(0, 3), l)
+	}
+	if _, e := l.CutE(1, 2); e != (IndexOutOfRange{ 2, 2 }) {
+		t.Fatalf("CutE(1, 2) should fail not %v", e)
+	}
+	if e := l.DeleteE(2, 1); e != (InvalidRange{ 2, 1 }) {
+		t.Fatalf("DeleteE(2, 1) should fail not %v", e)
+	}
+	if e := l.DeleteE(0, 0); e != nil || !l.Equal(ListOf(3)) {
+		t.Fatalf("DeleteE(0, 0) should be %v but is %v: %v", ListOf(3), l, e)
+	}
+}
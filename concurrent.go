@@ -0,0 +1,231 @@
+package lists
+
+import "iter"
+import "sync/atomic"
+
+/*
+	A ConcurrentList is a singly-linked list which may be used by many goroutines at once
+	without a lock, following the lists of Harris and Michael.
+
+	Each link between nodes is replaced atomically with compare-and-swap. An element is
+	deleted in two steps: first the link leaving its node is marked as deleted, which removes
+	the element logically and stops any further insertion after it, and then the node is
+	unlinked from its predecessor. Any traversal which finds a marked node helps to unlink it
+	before moving on, so a deletion never has to wait for another goroutine.
+
+	Positions are counted across the live elements at the moment of the traversal. As other
+	goroutines may be inserting and deleting at the same time, Len() is approximate and
+	iteration is weakly consistent: it sees every element which is present throughout the
+	iteration and may or may not see those which are inserted or deleted whilst it proceeds.
+*/
+
+type concurrentLink struct {
+	node		*concurrentNode
+	deleted		bool
+}
+
+type concurrentNode struct {
+	value		atomic.Pointer[interface{}]
+	next		atomic.Pointer[concurrentLink]
+}
+
+func newConcurrentNode(v interface{}, next *concurrentNode) (n *concurrentNode) {
+	n = &concurrentNode{}
+	n.value.Store(&v)
+	n.next.Store(&concurrentLink{ node: next })
+	return
+}
+
+func (n *concurrentNode) Content() interface{} {
+	return *n.value.Load()
+}
+
+type ConcurrentList struct {
+	head		*concurrentNode
+	length		atomic.Int64
+}
+
+func NewConcurrentList(items... interface{}) (l *ConcurrentList) {
+	l = &ConcurrentList{ head: newConcurrentNode(nil, nil) }
+	for _, v := range items {
+		l.Append(v)
+	}
+	return
+}
+
+//	Walks the live nodes of the list until match is true, unlinking any deleted nodes it passes.
+//	Returns the matching node together with its predecessor and the link between them, or a nil node and the
+//	last live node when there is no match. i is the position of the matching node or the number of live nodes.
+func (l *ConcurrentList) find(match func(int, *concurrentNode) bool) (pred *concurrentNode, link *concurrentLink, curr *concurrentNode, i int) {
+retry:
+	pred, i = l.head, 0
+	link = pred.next.Load()
+	for curr = link.node; curr != nil; curr = link.node {
+		next := curr.next.Load()
+		if next.deleted {
+			unlinked := &concurrentLink{ node: next.node }
+			if !pred.next.CompareAndSwap(link, unlinked) {
+				goto retry
+			}
+			link = unlinked
+			continue
+		}
+		if match(i, curr) {
+			return
+		}
+		pred, link = curr, next
+		i++
+	}
+	return
+}
+
+//	Returns the live node at position i without modifying the list
+func (l *ConcurrentList) node(i int) (n *concurrentNode) {
+	if i > -1 {
+		for n = l.head.next.Load().node; n != nil; n = n.next.Load().node {
+			if !n.next.Load().deleted {
+				if i == 0 {
+					break
+				}
+				i--
+			}
+		}
+	}
+	return
+}
+
+//	Returns the approximate number of elements in the list
+func (l *ConcurrentList) Len() int {
+	return int(l.length.Load())
+}
+
+//	Inserts v at position i, so that it follows the element currently at position i - 1.
+//	Returns false if the list holds fewer than i elements.
+func (l *ConcurrentList) Insert(i int, v interface{}) bool {
+	if i < 0 {
+		return false
+	}
+	for {
+		pred, link, curr, j := l.find(func(j int, n *concurrentNode) bool { return j == i })
+		if curr == nil && j < i {
+			return false
+		}
+		if pred.next.CompareAndSwap(link, &concurrentLink{ node: newConcurrentNode(v, curr) }) {
+			l.length.Add(1)
+			return true
+		}
+	}
+}
+
+//	Inserts v at the start of the list
+func (l *ConcurrentList) Push(v interface{}) {
+	l.Insert(0, v)
+}
+
+//	Inserts v at the end of the list
+func (l *ConcurrentList) Append(v interface{}) {
+	for {
+		pred, link, _, _ := l.find(func(int, *concurrentNode) bool { return false })
+		if pred.next.CompareAndSwap(link, &concurrentLink{ node: newConcurrentNode(v, nil) }) {
+			l.length.Add(1)
+			return
+		}
+	}
+}
+
+//	Removes the first live node for which match is true, reporting whether one was found
+func (l *ConcurrentList) remove(match func(int, *concurrentNode) bool) (ok bool) {
+	for {
+		pred, link, curr, _ := l.find(match)
+		if curr == nil {
+			return false
+		}
+		next := curr.next.Load()
+		if next.deleted {
+			continue
+		}
+		if curr.next.CompareAndSwap(next, &concurrentLink{ node: next.node, deleted: true }) {
+			l.length.Add(-1)
+			pred.next.CompareAndSwap(link, &concurrentLink{ node: next.node })
+			return true
+		}
+	}
+}
+
+//	Removes the first element equal to v, comparing elements as Equal does, and reports whether there was one
+func (l *ConcurrentList) Delete(v interface{}) bool {
+	return l.remove(func(i int, n *concurrentNode) bool { return equalValues(n.Content(), v) })
+}
+
+//	Removes the element at position i, reporting whether there was one
+func (l *ConcurrentList) DeleteAt(i int) bool {
+	return i > -1 && l.remove(func(j int, n *concurrentNode) bool { return j == i })
+}
+
+//	Determines whether the list holds an element equal to v without modifying the list
+func (l *ConcurrentList) Contains(v interface{}) bool {
+	for x := range l.Values() {
+		if equalValues(x, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *ConcurrentList) At(i int) (r interface{}) {
+	if n := l.node(i); n != nil {
+		r = n.Content()
+	}
+	return
+}
+
+func (l *ConcurrentList) Set(i int, v interface{}) {
+	if n := l.node(i); n != nil {
+		n.value.Store(&v)
+	}
+}
+
+func (l *ConcurrentList) Each(f func(interface{})) {
+	for v := range l.Values() {
+		f(v)
+	}
+}
+
+//	Returns an iterator over the position and value of each live element
+func (l *ConcurrentList) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		i := 0
+		for n := l.head.next.Load().node; n != nil; n = n.next.Load().node {
+			if !n.next.Load().deleted {
+				if !yield(i, n.Content()) {
+					return
+				}
+				i++
+			}
+		}
+	}
+}
+
+//	Returns an iterator over the value of each live element
+func (l *ConcurrentList) Values() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, v := range l.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+//	Returns a LinearList holding the elements found by iterating over the list
+func (l *ConcurrentList) List() (r *LinearList) {
+	r = List()
+	for v := range l.Values() {
+		r.appendValue(v)
+	}
+	return
+}
+
+func (l *ConcurrentList) String() string {
+	return l.List().String()
+}
@@ -0,0 +1,141 @@
+package lists
+
+import "sync"
+import "testing"
+
+func TestConcurrentList(t *testing.T) {
+	l := NewConcurrentList(0, 1, 2)
+	switch {
+	case l.Len() != 3:				t.Fatalf("Len() should be 3 not %v", l.Len())
+	case l.String() != "(0 1 2)":	t.Fatalf("String() should be (0 1 2) not %v", l)
+	}
+
+	ConfirmInsert := func(i int, v interface{}, r *LinearList) {
+		if !l.Insert(i, v) {
+			t.Fatalf("Insert(%v, %v) should succeed", i, v)
+		}
+		if x := l.List(); !x.Equal(r) {
+			t.Fatalf("Insert(%v, %v) should give %v not %v", i, v, r, x)
+		}
+	}
+	ConfirmInsert(0, "a", List("a", 0, 1, 2))
+	ConfirmInsert(2, "b", List("a", 0, "b", 1, 2))
+	ConfirmInsert(5, "c", List("a", 0, "b", 1, 2, "c"))
+	if l.Insert(7, "d") || l.Insert(-1, "d") {
+		t.Fatalf("Insert() should fail beyond the list")
+	}
+
+	ConfirmDelete := func(ok bool, r *LinearList, f func() bool) {
+		if f() != ok {
+			t.Fatalf("deletion from %v should report %v", l, ok)
+		}
+		if x := l.List(); !x.Equal(r) || l.Len() != r.Len() {
+			t.Fatalf("deletion should give %v not %v", r, x)
+		}
+	}
+	ConfirmDelete(true, List(0, "b", 1, 2, "c"), func() bool { return l.Delete("a") })
+	ConfirmDelete(false, List(0, "b", 1, 2, "c"), func() bool { return l.Delete("a") })
+	ConfirmDelete(true, List(0, "b", 2, "c"), func() bool { return l.DeleteAt(2) })
+	ConfirmDelete(true, List(0, "b", 2), func() bool { return l.DeleteAt(3) })
+	ConfirmDelete(false, List(0, "b", 2), func() bool { return l.DeleteAt(3) })
+
+	l.Set(1, 1)
+	l.Push(-1)
+	switch {
+	case l.At(2) != 1:				t.Fatalf("At(2) should be 1 not %v", l.At(2))
+	case l.At(4) != nil:			t.Fatalf("At(4) should be nil not %v", l.At(4))
+	case !l.Contains(2):			t.Fatalf("%v should contain 2", l)
+	case l.Contains("b"):			t.Fatalf("%v should not contain b", l)
+	}
+
+	var s Sequence = l
+	if s.Len() != 4 {
+		t.Fatalf("a ConcurrentList should be a Sequence")
+	}
+}
+
+func TestConcurrentListStress(t *testing.T) {
+	const writers, items = 8, 200
+	l := NewConcurrentList()
+	var w sync.WaitGroup
+	for g := 0; g < writers; g++ {
+		w.Add(1)
+		go func(g int) {
+			defer w.Done()
+			for i := 0; i < items; i++ {
+				if i % 2 == 0 {
+					l.Push(g * items + i)
+				} else {
+					l.Append(g * items + i)
+				}
+			}
+			for i := 0; i < items; i += 2 {
+				if !l.Delete(g * items + i) {
+					t.Errorf("Delete(%v) should find the element", g * items + i)
+				}
+			}
+		}(g)
+	}
+	for r := 0; r < 4; r++ {
+		w.Add(1)
+		go func() {
+			defer w.Done()
+			for i := 0; i < items; i++ {
+				l.Contains(i)
+				l.At(i)
+				l.Each(func(interface{}) {})
+				l.Insert(l.Len() / 2, -1)
+				if !l.Delete(-1) {
+					t.Errorf("Delete(-1) should find an inserted marker")
+				}
+			}
+		}()
+	}
+	w.Wait()
+
+	seen := make(map[interface{}]int)
+	l.Each(func(v interface{}) { seen[v]++ })
+	for g := 0; g < writers; g++ {
+		for i := 1; i < items; i += 2 {
+			if v := g * items + i; seen[v] != 1 {
+				t.Fatalf("%v should appear once not %v times", v, seen[v])
+			}
+		}
+		for i := 0; i < items; i += 2 {
+			if v := g * items + i; seen[v] != 0 {
+				t.Fatalf("%v should have been deleted", v)
+			}
+		}
+	}
+	if n := writers * items / 2; len(seen) != n || l.Len() != n {
+		t.Fatalf("Len() should be %v once the list is quiescent not %v", n, l.Len())
+	}
+}
+
+func TestConcurrentListDeleteRace(t *testing.T) {
+	l := NewConcurrentList()
+	for i := 0; i < 100; i++ {
+		l.Append(i)
+	}
+	var w sync.WaitGroup
+	deleted := make([]int, 4)
+	for g := range deleted {
+		w.Add(1)
+		go func(g int) {
+			defer w.Done()
+			for i := 0; i < 100; i++ {
+				if l.Delete(i) {
+					deleted[g]++
+				}
+			}
+		}(g)
+	}
+	w.Wait()
+	total := 0
+	for _, n := range deleted {
+		total += n
+	}
+	if total != 100 || l.Len() != 0 || l.At(0) != nil {
+		t.Fatalf("each element should be deleted exactly once, not %v times leaving %v", total, l)
+	}
+}
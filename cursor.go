@@ -0,0 +1,180 @@
+package lists
+
+import "github.com/feyeleanor/chain"
+
+/*
+	A Cursor marks a position in a list so that a sequence of edits can be made without
+	searching for the relevant node each time.
+
+	The cursor keeps track of the node preceding its current position so that insertion
+	before and removal of the current element are constant time operations even when the
+	list is singly linked. Edits made through a cursor keep the length, start and end of
+	the list correct, but edits made to the list by other means invalidate the cursor.
+
+	A cursor on a LinearList may be positioned just past the last element, in which case
+	it is not Valid() and InsertBefore() appends to the list. A cursor on a CycList wraps
+	around to the start of the loop when advanced past its end.
+*/
+type Cursor struct {
+	list		*ListHeader
+	previous	chain.Node
+	node		chain.Node
+	index		int
+	cyclic		bool
+}
+
+//	Returns a Cursor positioned at the given element of the list, or nil if there is no such position.
+func (l *ListHeader) Cursor(i int) *Cursor {
+	return l.cursor(i, l.length > 0 && l.next(l.end) == l.start)
+}
+
+func (l *ListHeader) cursor(i int, cyclic bool) (c *Cursor) {
+	if l != nil && i > -1 && (i < l.length || (i == l.length && (!cyclic || i == 0))) {
+		l.straighten()
+		c = &Cursor{ list: l, index: i, cyclic: cyclic }
+		switch {
+		case i > 0:			c.previous = l.findNode(i - 1)
+							c.node = chain.Next(c.previous)
+
+		case cyclic:		c.previous = l.end
+							c.node = l.start
+
+		default:			c.node = l.start
+		}
+	}
+	return
+}
+
+//	Returns a Cursor positioned at the given offset from the start of the loop
+func (c *CycList) Cursor(i int) *Cursor {
+	return c.ListHeader.cursor(c.index(i), true)
+}
+
+//	Reports whether the cursor is positioned at an element of the list
+func (c *Cursor) Valid() bool {
+	return c.node != nil
+}
+
+//	The offset of the cursor from the start of the list
+func (c *Cursor) Index() int {
+	return c.index
+}
+
+func (c *Cursor) Value() (r interface{}) {
+	if c.node != nil {
+		r = c.node.Content()
+	}
+	return
+}
+
+func (c *Cursor) Set(v interface{}) {
+	if c.node != nil {
+		c.node.Set(chain.CURRENT_NODE, v)
+	}
+}
+
+//	Advances the cursor to the following element, reporting whether it is still positioned at an element.
+//	On a CycList the cursor wraps around from the end of the loop to its start.
+func (c *Cursor) Next() bool {
+	if c.node != nil {
+		c.previous = c.node
+		if c.index++; c.cyclic {
+			c.node = chain.Next(c.node)
+			c.index %= c.list.length
+		} else {
+			if c.node == c.list.end {
+				c.node = nil
+			} else {
+				c.node = chain.Next(c.node)
+			}
+		}
+	}
+	return c.node != nil
+}
+
+//	Inserts a value into the list after the current element, leaving the cursor in place.
+//	When the cursor is on an empty loop the new element becomes its only element and the cursor is moved onto it.
+func (c *Cursor) InsertAfter(v interface{}) (ok bool) {
+	switch {
+	case c.node != nil:					n := c.list.NewListNode(v)
+										n.Link(chain.NEXT_NODE, chain.Next(c.node))
+										c.node.Link(chain.NEXT_NODE, n)
+										if c.node == c.list.end {
+											c.list.end = n
+										}
+										c.list.length++
+										c.list.invalidate(c.index + 1)
+										ok = true
+
+	case c.cyclic:						c.InsertBefore(v)
+										c.node, c.index = c.previous, 0
+										ok = true
+	}
+	return
+}
+
+//	Inserts a value into the list before the current element, leaving the cursor on the current element.
+//	When the cursor is past the end of a LinearList the value is appended to the list.
+func (c *Cursor) InsertBefore(v interface{}) {
+	n := c.list.NewListNode(v)
+	switch {
+	case c.list.length == 0:			c.list.start = n
+										c.list.end = n
+										if c.cyclic {
+											n.Link(chain.NEXT_NODE, n)
+										}
+
+	case c.node == nil:					c.list.end.Link(chain.NEXT_NODE, n)
+										c.list.end = n
+
+	default:							n.Link(chain.NEXT_NODE, c.node)
+										if c.previous != nil {
+											c.previous.Link(chain.NEXT_NODE, n)
+										}
+										if c.node == c.list.start {
+											c.list.start = n
+										}
+	}
+	c.previous = n
+	c.list.length++
+	c.list.invalidate(c.index)
+	c.index++
+}
+
+//	Removes the current element from the list and moves the cursor onto the element which followed it.
+func (c *Cursor) Remove() (ok bool) {
+	if c.node != nil {
+		next := chain.Next(c.node)
+		switch {
+		case c.list.length == 1:		c.list.Erase()
+										c.previous = nil
+										next = nil
+										c.index = 0
+
+		case c.previous == nil:			c.list.start = next
+										next.Link(chain.PREVIOUS_NODE, nil)
+
+		default:						c.previous.Link(chain.NEXT_NODE, next)
+										if c.node == c.list.start {
+											c.list.start = next
+										}
+		}
+
+		if c.node == c.list.end {
+			c.list.end = c.previous
+			if !c.cyclic {
+				next = nil
+			} else {
+				c.index = 0
+			}
+		}
+		c.node.Link(chain.NEXT_NODE, nil)
+		c.node = next
+		if c.list.length > 0 {
+			c.list.length--
+		}
+		c.list.invalidate(c.index)
+		ok = true
+	}
+	return
+}
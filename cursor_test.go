@@ -0,0 +1,166 @@
+package lists
+
+import "testing"
+
+func TestCursorNext(t *testing.T) {
+	l := List(0, 1, 2, 3)
+	c := l.Cursor(0)
+	for i := 0; i < l.Len(); i++ {
+		switch {
+		case !c.Valid():			t.Fatalf("cursor should be valid at %v", i)
+		case c.Index() != i:		t.Fatalf("cursor index should be %v but is %v", i, c.Index())
+		case c.Value() != i:		t.Fatalf("cursor value should be %v but is %v", i, c.Value())
+		}
+		c.Next()
+	}
+	if c.Valid() {
+		t.Fatalf("cursor should not be valid past the end of %v", l)
+	}
+
+	if c := l.Cursor(5); c != nil {
+		t.Fatalf("%v.Cursor(5) should be nil", l)
+	}
+	if c := l.Cursor(-1); c != nil {
+		t.Fatalf("%v.Cursor(-1) should be nil", l)
+	}
+}
+
+func TestCursorCyclicNext(t *testing.T) {
+	l := Loop(0, 1, 2)
+	c := l.Cursor(-1)
+	if c.Index() != 2 || c.Value() != 2 {
+		t.Fatalf("%v.Cursor(-1) should be at 2 not %v", l, c.Index())
+	}
+	for i := 0; i < 7; i++ {
+		if !c.Next() {
+			t.Fatalf("cursor should be valid after %v steps", i)
+		}
+		if x := i % 3; c.Index() != x || c.Value() != x {
+			t.Fatalf("cursor should be at %v but is at %v: %v", x, c.Index(), c.Value())
+		}
+	}
+}
+
+func TestCursorSet(t *testing.T) {
+	l := List(0, 1, 2, 3)
+	for c := l.Cursor(0); c.Valid(); c.Next() {
+		c.Set(c.Value().(int) * 10)
+	}
+	if r := List(0, 10, 20, 30); !l.Equal(r) {
+		t.Fatalf("%v should be %v", l, r)
+	}
+}
+
+func TestCursorInsertAfter(t *testing.T) {
+	ConfirmInsertAfter := func(l *LinearList, i int, v interface{}, r *LinearList) {
+		c := l.Cursor(i)
+		switch {
+		case !c.InsertAfter(v):		t.Fatalf("InsertAfter(%v) at %v should succeed", v, i)
+		case !l.Equal(r):			t.Fatalf("InsertAfter(%v) at %v should be %v but is %v", v, i, r, l)
+		case l.End() != l.findNode(l.Len() - 1):	t.Fatalf("InsertAfter(%v) at %v end of list is incorrect", v, i)
+		case c.Index() != i:		t.Fatalf("InsertAfter(%v) should leave cursor at %v not %v", v, i, c.Index())
+		}
+	}
+	ConfirmInsertAfter(List(0), 0, 1, List(0, 1))
+	ConfirmInsertAfter(List(0, 1, 2), 0, 3, List(0, 3, 1, 2))
+	ConfirmInsertAfter(List(0, 1, 2), 2, 3, List(0, 1, 2, 3))
+
+	if List().Cursor(0).InsertAfter(1) {
+		t.Fatalf("InsertAfter on an empty list should fail")
+	}
+
+	c := Loop()
+	if !c.Cursor(0).InsertAfter(1) || !c.Equal(Loop(1)) || c.At(1) != 1 {
+		t.Fatalf("InsertAfter on an empty loop should be %v but is %v", Loop(1), c)
+	}
+	c = Loop(0, 1)
+	c.Cursor(1).InsertAfter(2)
+	if !c.Equal(Loop(0, 1, 2)) || c.At(3) != 0 {
+		t.Fatalf("InsertAfter on the end of a loop should be %v but is %v", Loop(0, 1, 2), c)
+	}
+}
+
+func TestCursorInsertBefore(t *testing.T) {
+	ConfirmInsertBefore := func(l *LinearList, i int, v interface{}, r *LinearList) {
+		c := l.Cursor(i)
+		c.InsertBefore(v)
+		switch {
+		case !l.Equal(r):			t.Fatalf("InsertBefore(%v) at %v should be %v but is %v", v, i, r, l)
+		case l.Len() != r.Len():	t.Fatalf("InsertBefore(%v) at %v length should be %v not %v", v, i, r.Len(), l.Len())
+		case c.Index() != i + 1:	t.Fatalf("InsertBefore(%v) should move cursor to %v not %v", v, i + 1, c.Index())
+		}
+	}
+	ConfirmInsertBefore(List(), 0, 1, List(1))
+	ConfirmInsertBefore(List(0, 1, 2), 0, 3, List(3, 0, 1, 2))
+	ConfirmInsertBefore(List(0, 1, 2), 1, 3, List(0, 3, 1, 2))
+	ConfirmInsertBefore(List(0, 1, 2), 3, 3, List(0, 1, 2, 3))
+
+	l := List(0, 1, 2)
+	c := l.Cursor(0)
+	for c.Valid() {
+		c.InsertBefore(-1)
+		c.Next()
+	}
+	c.InsertBefore(-1)
+	if r := List(-1, 0, -1, 1, -1, 2, -1); !l.Equal(r) {
+		t.Fatalf("InsertBefore should be %v but is %v", r, l)
+	}
+
+	o := Loop(0, 1, 2)
+	o.Cursor(0).InsertBefore(3)
+	if r := Loop(3, 0, 1, 2); !o.Equal(r) || o.At(4) != 3 {
+		t.Fatalf("InsertBefore on a loop should be %v but is %v", r, o)
+	}
+}
+
+func TestCursorRemove(t *testing.T) {
+	ConfirmRemove := func(l *LinearList, i int, r *LinearList, v interface{}) {
+		c := l.Cursor(i)
+		switch {
+		case !c.Remove():			t.Fatalf("Remove() at %v should succeed", i)
+		case !l.Equal(r):			t.Fatalf("Remove() at %v should be %v but is %v", i, r, l)
+		case l.Len() != r.Len():	t.Fatalf("Remove() at %v length should be %v not %v", i, r.Len(), l.Len())
+		case c.Value() != v:		t.Fatalf("Remove() at %v should leave cursor on %v not %v", i, v, c.Value())
+		}
+	}
+	ConfirmRemove(List(0), 0, List(), nil)
+	ConfirmRemove(List(0, 1, 2), 0, List(1, 2), 1)
+	ConfirmRemove(List(0, 1, 2), 1, List(0, 2), 2)
+	ConfirmRemove(List(0, 1, 2), 2, List(0, 1), nil)
+
+	l := List(0, 1, 2, 3, 4, 5)
+	for c := l.Cursor(0); c.Valid(); c.Next() {
+		if c.Value().(int) % 2 == 1 {
+			c.Remove()
+		}
+	}
+	if r := List(0, 2, 4); !l.Equal(r) || l.End().Content() != 4 {
+		t.Fatalf("Remove() should be %v but is %v", r, l)
+	}
+
+	o := Loop(0, 1, 2)
+	c := o.Cursor(2)
+	switch {
+	case !c.Remove():				t.Fatalf("Remove() at end of loop should succeed")
+	case !o.Equal(Loop(0, 1)):		t.Fatalf("Remove() should be %v but is %v", Loop(0, 1), o)
+	case o.At(2) != 0:				t.Fatalf("Remove() should leave %v closed", o)
+	case c.Index() != 0:			t.Fatalf("Remove() should wrap cursor to 0 not %v", c.Index())
+	}
+	c.Remove()
+	c.Remove()
+	if o.Len() != 0 || c.Valid() {
+		t.Fatalf("Remove() should empty %v", o)
+	}
+}
+
+func TestCursorDoubleList(t *testing.T) {
+	l := DList(0, 1, 2, 3)
+	c := l.Cursor(0)
+	c.Remove()
+	c.Next()
+	c.InsertBefore(4)
+	if r := DList(1, 4, 2, 3); !l.Equal(r) {
+		t.Fatalf("%v should be %v", l, r)
+	}
+	confirmDoubleLinks(t, l.ListHeader)
+}
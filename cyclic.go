@@ -1,8 +1,8 @@
 package lists
 
 import (
+	"iter"
 	"github.com/feyeleanor/chain"
-	"github.com/feyeleanor/jittery"
 )
 
 /*
@@ -36,21 +36,36 @@ func (c CycList) Clone() (r *CycList) {
 }
 
 //	Iterate over all elements of the list indefinitely
-//	The only way to terminate iteration is by raising a panic() in the applied function
+//	The only way to terminate iteration is by raising a panic() in the applied function, so Cycling() is usually preferable
 func (c CycList) Cycle(f func(interface{})) {
 	for n := c.start; ; n = chain.Next(n) {
 		f(n.Content())
 	}
 }
 
-func (c CycList) index(i int) (r int) {
+//	Returns an iterator which cycles over all elements of the list indefinitely.
+//	Iteration terminates when the range loop is broken out of, or immediately if the list is empty.
+func (c CycList) Cycling() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		if c.length > 0 {
+			for n := c.start; yield(n.Content()); n = chain.Next(n) {}
+		}
+	}
+}
+
+func (c CycList) index(i int) int {
+	return cyclicIndex(i, c.length)
+}
+
+//	Maps an arbitrary offset onto a position in a loop of the given length
+func cyclicIndex(i, length int) (r int) {
 	switch {
-	case c.length == 0:
+	case length == 0:
 		r = 0
 	case i > 0:
-		r = i % c.length
+		r = i % length
 	case i < 0:
-		r = c.length + (i % c.length)
+		r = (length + (i % length)) % length
 	}
 	return
 }
@@ -67,21 +82,34 @@ func (c CycList) Set(i int, v interface{}) {
 
 func (c *CycList) Rotate(i int) {
 	if c != nil && c.end != nil {
-		c.end = c.end.MoveTo(c.index(i))
-		c.start = chain.Next(c.end)
+		if i = c.index(i); i != 0 {
+			c.end = c.end.MoveTo(i)
+			c.start = chain.Next(c.end)
+			c.invalidate(0)
+		}
 	}
 }
 
 func (c *CycList) Append(v interface{}) {
-	if c.ListHeader.Append(v); c.end == c.start {
+	if c.appendValue(v); c.end == c.start {
 		c.end.Link(chain.NEXT_NODE, c.start)
 	}
+	c.check()
 }
 
 func (c *CycList) Concatenate(i interface{}) {
-	if c.ListHeader.Concatenate(i); c.end != nil {
+	if c.concatenate(i); c.end != nil {
 		c.end.Link(chain.NEXT_NODE, c.start)
 	}
+	c.check()
+}
+
+//	Writes the loop in the notation read by Parse(), marking it as a loop with a final ... even when it is empty
+func (c CycList) String() (t string) {
+	if t = "(...)"; c.length > 0 {
+		t = c.ListHeader.String()
+	}
+	return
 }
 
 //	Determines if another object is equivalent to the CycList
@@ -92,10 +120,6 @@ func (c CycList) Equal(o interface{}) (r bool) {
 		r = o != nil && c.ListHeader.Equal(o.ListHeader)
 	case CycList:
 		r = c.ListHeader.Equal(o.ListHeader)
-	default:
-		jittery.Catch(func() {
-			r = c.start.(Equatable).Equal(o)
-		})
 	}
 	return 
 }
@@ -104,11 +128,171 @@ func (c CycList) Equal(o interface{}) (r bool) {
 func (c *CycList) Reverse() {
 	if c != nil {
 		c.ListHeader.Reverse()
-		c.end.Link(chain.NEXT_NODE, c.start)
 	}
 }
 
+//	Inserts n nil elements before the element at the given offset, which wraps around the loop, or at the end of the
+//	loop when the offset is Len()
+func (c *CycList) Expand(i, n int) {
+	if c != nil {
+		i = c.insertionIndex(i)
+		c.linear(func(l *LinearList) { l.Expand(i, n) })
+	}
+}
+
+//	Removes the first element of the loop, linking the end to the new start
 func (c *CycList) Tail() {
-	c.ListHeader.Tail()
-	c.end = c.start
-}
\ No newline at end of file
+	if c != nil {
+		c.ListHeader.Tail()
+	}
+}
+
+//	Opens the loop into a LinearList for the duration of f, closing it again afterwards.
+//	Debug validation is deferred until the loop has been closed.
+func (c *CycList) linear(f func(l *LinearList)) {
+	l := &LinearList{ c.ListHeader }
+	l.debug = debugOff
+	if l.end != nil {
+		l.end.Link(chain.NEXT_NODE, nil)
+	}
+	f(l)
+	l.debug = c.debug
+	c.ListHeader = l.ListHeader
+	c.closeLoop()
+	c.check()
+}
+
+//	Maps a position for insertion onto the loop. Insertion at Len() adds to the end of the loop rather than wrapping to its start.
+func (c CycList) insertionIndex(i int) int {
+	if i != c.length {
+		i = c.index(i)
+	}
+	return i
+}
+
+//	Removes the elements in the range from the loop.
+//	Offsets wrap around the loop as they do for At, and a range whose end falls before its start runs across the end of the loop.
+func (c *CycList) Delete(from, to int) {
+	if c != nil && c.length > 0 {
+		from, to = c.index(from), c.index(to)
+		c.linear(func(l *LinearList) {
+			if from > to {
+				l.Delete(from, l.length - 1)
+				from = 0
+			}
+			l.Delete(from, to)
+		})
+	}
+}
+
+//	Inserts an item into the loop at the given offset, which wraps around the loop except that Len() is the end of the loop
+func (c *CycList) Insert(i int, o interface{}) {
+	if c != nil {
+		i = c.insertionIndex(i)
+		c.linear(func(l *LinearList) {
+			l.Insert(i, o)
+		})
+	}
+}
+
+//	Removes the elements in the range from the loop and returns a new loop containing them.
+//	The range is interpreted as it is by Delete.
+func (c *CycList) Cut(from, to int) (r CycList) {
+	if c != nil {
+		r.nodeType = c.nodeType
+		if c.length > 0 {
+			from, to = c.index(from), c.index(to)
+			c.linear(func(l *LinearList) {
+				if from > to {
+					x := l.Cut(from, l.length - 1)
+					y := l.Cut(0, to)
+					x.Absorb(x.length, &y)
+					r.ListHeader = x.ListHeader
+				} else {
+					r.ListHeader = l.Cut(from, to).ListHeader
+				}
+			})
+			r.closeLoop()
+		}
+	}
+	return
+}
+
+//	Takes all the elements from another loop and inserts them into this loop at the given offset, destroying the other loop if successful.
+//	The offset is interpreted as it is by Insert.
+func (c *CycList) Absorb(i int, o *CycList) (ok bool) {
+	if c != nil && o != nil && o != c {
+		i = c.insertionIndex(i)
+		o.linear(func(x *LinearList) {
+			c.linear(func(l *LinearList) {
+				ok = l.Absorb(i, x)
+			})
+		})
+	}
+	return
+}
+
+//	Advances the start of the loop by k elements and returns the element now at its start.
+//	Treating the start as the current position allows the loop to be used for round-robin scheduling,
+//	with Delete(0, 0) removing the current element in constant time.
+func (c *CycList) Step(k int) (r interface{}, ok bool) {
+	if c != nil {
+		c.Rotate(k)
+		r, ok = c.PeekFront()
+	}
+	return
+}
+
+//	Repeatedly counts k elements around the loop from its start and removes the k-th, calling visit with each element removed.
+//	Counting resumes at the element following the one removed, so the elements are removed in the order of the Josephus problem.
+//	Elimination continues until the loop is empty or visit returns false, and the loop is left starting at the element after the last removed.
+func (c *CycList) RemoveEvery(k int, visit func(interface{}) bool) {
+	if c != nil && k > 0 && c.length > 0 {
+		x := c.Cursor(0)
+		for more := true; more && c.length > 0; {
+			for i := (k - 1) % c.length; i > 0; i-- {
+				x.Next()
+			}
+			v := x.Value()
+			x.Remove()
+			more = visit(v)
+		}
+		if c.length > 0 {
+			c.end = x.previous
+			c.start = x.node
+			c.invalidate(0)
+		}
+	}
+}
+
+//	Cuts the loop at the elements with offsets i and j, keeping the elements from i up to but not including j in the
+//	loop and returning the remaining elements from j as another loop. The loop itself is returned as r. The offsets
+//	wrap around the loop, so when they refer to the same element the loop is left empty. The nodes are relinked
+//	rather than copied.
+func (c *CycList) Split(i, j int) (r, s *CycList) {
+	if c != nil {
+		r, s = c, &CycList{ *c.newHeader() }
+		if c.length > 0 {
+			i, j = c.index(i), c.index(j)
+			n := c.index(j - i)
+			a, b := c.findNode(i), c.findNode(j)
+			x, y := c.findNode(c.index(i - 1)), c.findNode(c.index(j - 1))
+			s.start, s.end, s.length = b, x, c.length - n
+			s.closeLoop()
+			if n > 0 {
+				c.start, c.end, c.length = a, y, n
+				c.closeLoop()
+			} else {
+				c.start, c.end, c.length = nil, nil, 0
+			}
+			c.invalidate(0)
+		}
+	}
+	return
+}
+
+//	Splices another loop into the loop before the element at the given offset, which wraps around the loop, so that
+//	the two become a single loop. The nodes are relinked rather than copied, leaving the other loop empty.
+func (c *CycList) Join(o *CycList, at int) bool {
+	return c.Absorb(c.index(at), o)
+}
@@ -1,6 +1,7 @@
 package lists
 
 import "github.com/feyeleanor/chain"
+import "reflect"
 import "testing"
 
 func TestCycListLen(t *testing.T) {
@@ -43,10 +44,11 @@ func TestCycListEach(t *testing.T) {
 }
 
 func TestCycListCycle(t *testing.T) {
+	type finished struct{}
 	c := Loop(0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
 	count := 0
 	defer func() {
-		if x := recover(); x != nil {
+		if x := recover(); x != (finished{}) {
 			t.Fatalf("element %v erroneously reported as %v", count, x)
 		}
 	}()
@@ -56,7 +58,7 @@ func TestCycListCycle(t *testing.T) {
 		}
 		count++
 		if count == c.Len() {
-			panic(nil)
+			panic(finished{})
 		}
 	})
 }
@@ -104,6 +106,9 @@ func TestCycListRotate(t *testing.T) {
 			t.Fatalf("%v should be %v", c, r)
 		}
 	}
+	var c *CycList
+	c.Rotate(1)
+
 	ConfirmRotate(Loop(), 0, Loop())
 	ConfirmRotate(Loop(), 1, Loop())
 	ConfirmRotate(Loop(), 2, Loop())
@@ -156,7 +161,7 @@ func TestCycListString(t *testing.T) {
 		}
 	}
 
-	ConfirmFormat(Loop(), "()")
+	ConfirmFormat(Loop(), "(...)")
 	ConfirmFormat(Loop(0), "(0 ...)")
 	ConfirmFormat(Loop(0, nil), "(0 nil ...)")
 	ConfirmFormat(Loop(0, Loop(0)), "(0 (0 ...) ...)")
@@ -171,7 +176,7 @@ func TestCycListString(t *testing.T) {
 	ConfirmFormat(r.start.Content().(*CycList), "(0 (0 ...) ...)")
 
 	r = Loop(r, 0, Loop(-1, -2, r))
-	ConfirmFormat(r, "(((0 (0 ...) ...) 10 ...) 0 (-1 -2 ((0 (0 ...) ...) 10 ...) ...) ...)")
+	ConfirmFormat(r, "(#1=((0 (0 ...) ...) 10 ...) 0 (-1 -2 #1# ...) ...)")
 }
 
 func TestLoop(t *testing.T) {
@@ -180,7 +185,7 @@ func TestLoop(t *testing.T) {
 			t.Fatalf("'%v' erroneously serialised as '%v'", x, s)
 		}
 	}
-	ConfirmFormat(Loop(), "()")
+	ConfirmFormat(Loop(), "(...)")
 	ConfirmFormat(Loop(1), "(1 ...)")
 	ConfirmFormat(Loop(2, 1), "(2 1 ...)")
 	ConfirmFormat(Loop(3, 2, 1), "(3 2 1 ...)")
@@ -201,6 +206,8 @@ func TestCycListReverse(t *testing.T) {
 		}
 	}
 
+	ConfirmReverse(Loop(), Loop())
+
 	c := Loop(1)
 	ConfirmReverse(c, Loop(1))
 	ConfirmReverse(c, Loop(1))
@@ -266,4 +273,284 @@ func TestCycListCompact(t *testing.T) {
 	ConfirmCompact(Loop(), []interface{}{})
 	ConfirmCompact(Loop(1), []interface{}{ 1 })
 	ConfirmCompact(Loop(0, 1, 2, 3, 4, 5, 6, 7, 8, 9), []interface{}{ 0, 1, 2, 3, 4, 5, 6, 7, 8, 9 })
-}
\ No newline at end of file
+}
+
+func TestCycListExpand(t *testing.T) {
+	ConfirmExpand := func(c *CycList, i, n int, r *CycList) {
+		c.Expand(i, n)
+		switch {
+		case !r.Equal(c):				t.Fatalf("Expand(%v, %v) should be %v but is %v", i, n, r, c)
+		case c.Validate() != nil:		t.Fatalf("Expand(%v, %v) should leave a closed loop: %v", i, n, c.Validate())
+		}
+	}
+	ConfirmExpand(Loop(), 0, 2, Loop(nil, nil))
+	ConfirmExpand(Loop(0, 1), 0, 1, Loop(nil, 0, 1))
+	ConfirmExpand(Loop(0, 1), 1, 2, Loop(0, nil, nil, 1))
+	ConfirmExpand(Loop(0, 1), 2, 1, Loop(0, 1, nil))
+	ConfirmExpand(Loop(0, 1), -1, 1, Loop(0, nil, 1))
+	ConfirmExpand(Loop(0, 1), 3, 1, Loop(0, nil, 1))
+}
+
+func TestCycListTail(t *testing.T) {
+	ConfirmTail := func(c, r *CycList) {
+		c.Tail()
+		switch {
+		case !c.Equal(r):							t.Fatalf("Tail should be '%v' but is '%v'", r, c)
+		case c.Validate() != nil:					t.Fatalf("Tail should leave %v valid: %v", c, c.Validate())
+		case c.Len() > 0 && c.End().Content() != r.End().Content():
+													t.Fatalf("Tail should leave %v ending with %v", c, r.End().Content())
+		}
+	}
+	ConfirmTail(Loop(), Loop())
+	ConfirmTail(Loop(0), Loop())
+	ConfirmTail(Loop(0, 1), Loop(1))
+	ConfirmTail(Loop(0, 1, 2), Loop(1, 2))
+
+	var c *CycList
+	c.Tail()
+}
+
+func TestCycListAll(t *testing.T) {
+	c := Loop(0, 1, 2, 3, 4)
+	count := 0
+	for i, v := range c.All() {
+		if i != count || v != count {
+			t.Fatalf("element %v erroneously reported as %v: %v", count, i, v)
+		}
+		count++
+	}
+	if count != c.Len() {
+		t.Fatalf("loop length %v erroneously reported iterations as %v", c.Len(), count)
+	}
+}
+
+func TestCycListBackward(t *testing.T) {
+	c := Loop(0, 1, 2, 3, 4)
+	count := c.Len()
+	for i, v := range c.Backward() {
+		count--
+		if i != count || v != count {
+			t.Fatalf("element %v erroneously reported as %v: %v", count, i, v)
+		}
+	}
+	if count != 0 {
+		t.Fatalf("backward iteration should visit %v elements", c.Len())
+	}
+}
+
+func TestCycListCycling(t *testing.T) {
+	c := Loop(0, 1, 2, 3, 4)
+	count := 0
+	for v := range c.Cycling() {
+		if v != count % c.Len() {
+			t.Fatalf("element %v erroneously reported as %v", count, v)
+		}
+		if count++; count == 3 * c.Len() {
+			break
+		}
+	}
+
+	for range Loop().Cycling() {
+		t.Fatalf("empty loop should not be iterated")
+	}
+}
+
+func TestCycListDelete(t *testing.T) {
+	ConfirmDelete := func(c *CycList, from, to int, r *CycList) {
+		c.Debug(true)
+		c.Delete(from, to)
+		switch {
+		case !r.Equal(c):						t.Fatalf("Delete(%v, %v) should be %v but is %v", from, to, r, c)
+		case c.Len() > 0 && c.At(c.Len()) != c.At(0):	t.Fatalf("Delete(%v, %v) should leave a closed loop", from, to)
+		}
+	}
+	ConfirmDelete(Loop(), 0, 0, Loop())
+	ConfirmDelete(Loop(0), 0, 0, Loop())
+	ConfirmDelete(Loop(0, 1, 2, 3, 4), 0, 0, Loop(1, 2, 3, 4))
+	ConfirmDelete(Loop(0, 1, 2, 3, 4), 4, 4, Loop(0, 1, 2, 3))
+	ConfirmDelete(Loop(0, 1, 2, 3, 4), 1, 3, Loop(0, 4))
+	ConfirmDelete(Loop(0, 1, 2, 3, 4), 0, 4, Loop())
+	ConfirmDelete(Loop(0, 1, 2, 3, 4), 6, 7, Loop(0, 3, 4))
+	ConfirmDelete(Loop(0, 1, 2, 3, 4), -1, -1, Loop(0, 1, 2, 3))
+	ConfirmDelete(Loop(0, 1, 2, 3, 4), 3, 1, Loop(2))
+	ConfirmDelete(Loop(0, 1, 2, 3, 4), 4, 0, Loop(1, 2, 3))
+}
+
+func TestCycListInsert(t *testing.T) {
+	ConfirmInsert := func(c *CycList, i int, v interface{}, r *CycList) {
+		c.Debug(true)
+		c.Insert(i, v)
+		switch {
+		case !r.Equal(c):					t.Fatalf("Insert(%v, %v) should be %v but is %v", i, v, r, c)
+		case c.At(c.Len()) != c.At(0):		t.Fatalf("Insert(%v, %v) should leave a closed loop", i, v)
+		}
+	}
+	ConfirmInsert(Loop(), 0, 9, Loop(9))
+	ConfirmInsert(Loop(), 3, 9, Loop(9))
+	ConfirmInsert(Loop(0, 1, 2), 0, 9, Loop(9, 0, 1, 2))
+	ConfirmInsert(Loop(0, 1, 2), 1, 9, Loop(0, 9, 1, 2))
+	ConfirmInsert(Loop(0, 1, 2), 3, 9, Loop(0, 1, 2, 9))
+	ConfirmInsert(Loop(0, 1, 2), 4, 9, Loop(0, 9, 1, 2))
+	ConfirmInsert(Loop(0, 1, 2), -1, 9, Loop(0, 1, 9, 2))
+}
+
+func TestCycListCut(t *testing.T) {
+	ConfirmCut := func(c *CycList, from, to int, r, x *CycList) {
+		c.Debug(true)
+		y := c.Cut(from, to)
+		switch {
+		case !r.Equal(c):							t.Fatalf("Cut(%v, %v) should leave %v but leaves %v", from, to, r, c)
+		case !x.Equal(y):							t.Fatalf("Cut(%v, %v) should return %v but returns %v", from, to, x, y)
+		case y.Validate() != nil:					t.Fatalf("Cut(%v, %v) should return a closed loop: %v", from, to, y.Validate())
+		case c.Validate() != nil:					t.Fatalf("Cut(%v, %v) should leave a closed loop: %v", from, to, c.Validate())
+		}
+	}
+	ConfirmCut(Loop(), 0, 0, Loop(), Loop())
+	ConfirmCut(Loop(0, 1, 2, 3, 4), 0, 0, Loop(1, 2, 3, 4), Loop(0))
+	ConfirmCut(Loop(0, 1, 2, 3, 4), 1, 3, Loop(0, 4), Loop(1, 2, 3))
+	ConfirmCut(Loop(0, 1, 2, 3, 4), 0, 4, Loop(), Loop(0, 1, 2, 3, 4))
+	ConfirmCut(Loop(0, 1, 2, 3, 4), 3, 1, Loop(2), Loop(3, 4, 0, 1))
+}
+
+func TestCycListAbsorb(t *testing.T) {
+	ConfirmAbsorb := func(c *CycList, i int, o, r *CycList) {
+		c.Debug(true)
+		if !c.Absorb(i, o) {
+			t.Fatalf("Absorb(%v, %v) should succeed", i, o)
+		}
+		switch {
+		case !r.Equal(c):				t.Fatalf("Absorb(%v) should be %v but is %v", i, r, c)
+		case o.Len() != 0:				t.Fatalf("Absorb(%v) should empty the absorbed loop", i)
+		case c.Validate() != nil:		t.Fatalf("Absorb(%v) should leave a closed loop: %v", i, c.Validate())
+		}
+	}
+	ConfirmAbsorb(Loop(), 0, Loop(0, 1), Loop(0, 1))
+	ConfirmAbsorb(Loop(0, 1), 0, Loop(), Loop(0, 1))
+	ConfirmAbsorb(Loop(0, 1), 0, Loop(8, 9), Loop(8, 9, 0, 1))
+	ConfirmAbsorb(Loop(0, 1), 1, Loop(8, 9), Loop(0, 8, 9, 1))
+	ConfirmAbsorb(Loop(0, 1), 2, Loop(8, 9), Loop(0, 1, 8, 9))
+
+	c := Loop(0, 1)
+	if c.Absorb(0, c) || c.Absorb(0, nil) || !c.Equal(Loop(0, 1)) {
+		t.Fatalf("Absorb() should refuse to absorb a loop into itself or a nil loop")
+	}
+}
+
+func TestCycListStep(t *testing.T) {
+	c := Loop(0, 1, 2, 3)
+	ConfirmStep := func(k int, r interface{}) {
+		if v, ok := c.Step(k); v != r || !ok {
+			t.Fatalf("Step(%v) should reach %v not %v", k, r, v)
+		}
+	}
+	ConfirmStep(0, 0)
+	ConfirmStep(1, 1)
+	ConfirmStep(2, 3)
+	ConfirmStep(5, 0)
+	ConfirmStep(-1, 3)
+
+	c.Delete(0, 0)
+	if !c.Equal(Loop(0, 1, 2)) {
+		t.Fatalf("Delete(0, 0) should remove the current element leaving (0 1 2) not %v", c)
+	}
+	if _, ok := Loop().Step(1); ok {
+		t.Fatalf("Step() should fail on an empty loop")
+	}
+}
+
+func TestCycListRemoveEvery(t *testing.T) {
+	ConfirmRemoveEvery := func(c *CycList, k int, r []interface{}) {
+		var removed []interface{}
+		c.Debug(true)
+		c.RemoveEvery(k, func(v interface{}) bool {
+			removed = append(removed, v)
+			return true
+		})
+		if !reflect.DeepEqual(removed, r) || c.Len() != 0 {
+			t.Fatalf("RemoveEvery(%v) should remove %v not %v", k, r, removed)
+		}
+	}
+	ConfirmRemoveEvery(Loop(), 2, nil)
+	ConfirmRemoveEvery(Loop(1), 3, []interface{}{ 1 })
+	ConfirmRemoveEvery(Loop(1, 2, 3, 4, 5), 1, []interface{}{ 1, 2, 3, 4, 5 })
+	ConfirmRemoveEvery(Loop(1, 2, 3, 4, 5, 6, 7), 3, []interface{}{ 3, 6, 2, 7, 5, 1, 4 })
+	ConfirmRemoveEvery(Loop(1, 2, 3, 4, 5), 2, []interface{}{ 2, 4, 1, 5, 3 })
+
+	c := Loop(1, 2, 3, 4, 5, 6, 7)
+	n := 0
+	c.RemoveEvery(3, func(interface{}) bool {
+		n++
+		return n < 5
+	})
+	if !c.Equal(Loop(1, 4)) || c.Validate() != nil {
+		t.Fatalf("RemoveEvery() should stop when visit returns false leaving (1 4) not %v", c)
+	}
+
+	c = Loop(0, 1)
+	c.RemoveEvery(0, func(interface{}) bool { return true })
+	if c.Len() != 2 {
+		t.Fatalf("RemoveEvery(0) should remove nothing")
+	}
+}
+
+func TestCycListSplit(t *testing.T) {
+	ConfirmSplit := func(c *CycList, i, j int, x, y *CycList) {
+		c.Debug(true)
+		nodes := make(map[chain.Node]bool)
+		c.eachNode(func(_ int, n chain.Node) { nodes[n] = true })
+		r, s := c.Split(i, j)
+		switch {
+		case !x.Equal(r):				t.Fatalf("Split(%v, %v) should give %v not %v", i, j, x, r)
+		case !y.Equal(s):				t.Fatalf("Split(%v, %v) should give %v not %v", i, j, y, s)
+		case r.Validate() != nil:		t.Fatalf("Split(%v, %v) should give a closed loop: %v", i, j, r.Validate())
+		case s.Validate() != nil:		t.Fatalf("Split(%v, %v) should give a closed loop: %v", i, j, s.Validate())
+		case r != c:					t.Fatalf("Split(%v, %v) should keep the first loop in the receiver", i, j)
+		}
+		r.eachNode(func(_ int, n chain.Node) { delete(nodes, n) })
+		s.eachNode(func(_ int, n chain.Node) { delete(nodes, n) })
+		if len(nodes) != 0 {
+			t.Fatalf("Split(%v, %v) should reuse the nodes of the loop", i, j)
+		}
+	}
+	ConfirmSplit(Loop(), 0, 1, Loop(), Loop())
+	ConfirmSplit(Loop(0), 0, 0, Loop(), Loop(0))
+	ConfirmSplit(Loop(0, 1), 0, 1, Loop(0), Loop(1))
+	ConfirmSplit(Loop(0, 1, 2, 3, 4), 1, 3, Loop(1, 2), Loop(3, 4, 0))
+	ConfirmSplit(Loop(0, 1, 2, 3, 4), 3, 1, Loop(3, 4, 0), Loop(1, 2))
+	ConfirmSplit(Loop(0, 1, 2, 3, 4), 0, 4, Loop(0, 1, 2, 3), Loop(4))
+	ConfirmSplit(Loop(0, 1, 2, 3, 4), 2, 2, Loop(), Loop(2, 3, 4, 0, 1))
+	ConfirmSplit(Loop(0, 1, 2, 3, 4), -1, 6, Loop(4, 0), Loop(1, 2, 3))
+
+	var c *CycList
+	if r, s := c.Split(0, 1); r != nil || s != nil {
+		t.Fatalf("Split() of a nil loop should give nil loops")
+	}
+}
+
+func TestCycListJoin(t *testing.T) {
+	ConfirmJoin := func(c *CycList, o *CycList, at int, r *CycList) {
+		c.Debug(true)
+		n, p := o.start, c.index(at)
+		if !c.Join(o, at) {
+			t.Fatalf("Join(%v, %v) should succeed", o, at)
+		}
+		switch {
+		case !r.Equal(c):				t.Fatalf("Join(..., %v) should give %v not %v", at, r, c)
+		case c.Validate() != nil:		t.Fatalf("Join(..., %v) should leave a closed loop: %v", at, c.Validate())
+		case o.Len() != 0:				t.Fatalf("Join(..., %v) should leave the other loop empty", at)
+		case n != nil && c.findNode(p) != n:	t.Fatalf("Join(..., %v) should relink the nodes of the other loop", at)
+		}
+	}
+	ConfirmJoin(Loop(), Loop(0, 1), 0, Loop(0, 1))
+	ConfirmJoin(Loop(0, 1), Loop(), 1, Loop(0, 1))
+	ConfirmJoin(Loop(0, 1, 2), Loop(8, 9), 0, Loop(8, 9, 0, 1, 2))
+	ConfirmJoin(Loop(0, 1, 2), Loop(8, 9), 2, Loop(0, 1, 8, 9, 2))
+	ConfirmJoin(Loop(0, 1, 2), Loop(8, 9), 3, Loop(8, 9, 0, 1, 2))
+	ConfirmJoin(Loop(0, 1, 2), Loop(8, 9), -1, Loop(0, 1, 8, 9, 2))
+
+	c := Loop(0, 1, 2, 3, 4)
+	r, s := c.Split(1, 3)
+	if r.Join(s, 2); !r.Equal(Loop(3, 4, 0, 1, 2)) || !r.EquivalentRotation(Loop(0, 1, 2, 3, 4)) {
+		t.Fatalf("joining the loops from Split() should restore the cycle not give %v", r)
+	}
+}
@@ -0,0 +1,207 @@
+package lists
+
+import "github.com/feyeleanor/chain"
+
+/*
+	The deque operations allow a list to be used as a queue or a stack, adding and removing
+	elements at either end. Each Pop and Peek reports whether the list held an element, which
+	distinguishes a nil element from an empty list.
+
+	A node of a singly linked list cannot find its predecessor, so PopBack must walk from the
+	start of the list to the node before the end. The nodes passed are remembered so that a
+	run of PopBacks walks the list only once. The remembered nodes are those at the positions
+	from predecessorBase onwards, so pushing or popping at the start of the list just moves that
+	base, whilst those at or after any other modification are forgotten in the same way as
+	cached positions. The walk therefore costs amortised constant time per PopBack however the
+	ends are mixed, using at most one remembered pointer per element.
+
+	A doubly linked node knows its predecessor, so DoubleList and DoubleLoop remove from either
+	end in constant time by way of Remove and need remember nothing.
+*/
+
+//	Discards the remembered predecessors at or after position i, along with any no longer before the end of the list
+func (l *ListHeader) forgetPredecessors(i int) {
+	if i > l.length - 1 {
+		i = l.length - 1
+	}
+	switch i -= l.predecessorBase; {
+	case i <= 0:						l.predecessors, l.predecessorBase = nil, 0
+	case i < len(l.predecessors):		l.predecessors = l.predecessors[:i:i]
+	}
+}
+
+//	Moves the remembered predecessors by d positions after elements are added to or removed from the start of the list
+func (l *ListHeader) shiftPredecessors(d int) {
+	if l.predecessorBase += d; l.predecessorBase < 0 {
+		if -l.predecessorBase < len(l.predecessors) {
+			l.predecessors = l.predecessors[-l.predecessorBase:]
+		} else {
+			l.predecessors = nil
+		}
+		l.predecessorBase = 0
+	}
+	if len(l.predecessors) == 0 {
+		l.predecessors, l.predecessorBase = nil, 0
+	}
+}
+
+//	Returns the node before the end of a list of two or more elements, forgetting it as it is about to become the end
+func (l *ListHeader) predecessor() (n chain.Node) {
+	target := l.length - 2 - l.predecessorBase
+	if target < 0 {
+		l.predecessors, l.predecessorBase = nil, 0
+		target = l.length - 2
+	}
+	if i := len(l.predecessors) - 1; i < target {
+		if i == -1 {
+			n = l.start
+			l.predecessors = append(l.predecessors, n)
+			i = 0
+		} else {
+			n = l.predecessors[i]
+		}
+		for ; i < target; i++ {
+			n = chain.Next(n)
+			l.predecessors = append(l.predecessors, n)
+		}
+	}
+	n = l.predecessors[target]
+	if l.predecessors = l.predecessors[:target:target]; target == 0 {
+		l.predecessors, l.predecessorBase = nil, 0
+	}
+	return
+}
+
+//	Returns the first element of the list
+func (l ListHeader) PeekFront() (r interface{}, ok bool) {
+	if ok = l.start != nil; ok {
+		r = l.start.Content()
+	}
+	return
+}
+
+//	Returns the last element of the list
+func (l ListHeader) PeekBack() (r interface{}, ok bool) {
+	if ok = l.end != nil; ok {
+		r = l.end.Content()
+	}
+	return
+}
+
+//	Removes and returns the first element of the list
+func (l *ListHeader) PopFront() (r interface{}, ok bool) {
+	if r, ok = l.PeekFront(); ok {
+		l.Tail()
+	}
+	return
+}
+
+//	Removes and returns the last element of the list
+func (l *ListHeader) PopBack() (r interface{}, ok bool) {
+	if r, ok = l.PeekBack(); ok {
+		l.straighten()
+		n := l.end
+		if l.length == 1 {
+			l.start = nil
+			l.end = nil
+		} else {
+			closed := chain.Next(n) == l.start
+			l.end = l.predecessor()
+			if closed {
+				l.end.Link(chain.NEXT_NODE, l.start)
+			} else {
+				l.end.Link(chain.NEXT_NODE, nil)
+			}
+		}
+		n.Link(chain.NEXT_NODE, nil)
+		l.length--
+		l.invalidate(l.length)
+	}
+	return
+}
+
+//	Inserts a node holding v at the start of the list, linking the end to it when the list is closed
+func (l *ListHeader) pushFront(v interface{}, closed bool) {
+	l.straighten()
+	n := l.NewListNode(v)
+	if l.start == nil {
+		l.end = n
+	} else {
+		n.Link(chain.NEXT_NODE, l.start)
+	}
+	l.start = n
+	if closed {
+		l.end.Link(chain.NEXT_NODE, l.start)
+	}
+	l.length++
+	l.shiftPredecessors(1)
+	l.modified(0)
+}
+
+
+func (l *LinearList) PushFront(v interface{}) {
+	l.pushFront(v, false)
+}
+
+func (l *LinearList) PushBack(v interface{}) {
+	l.Append(v)
+}
+
+
+func (c *CycList) PushFront(v interface{}) {
+	c.pushFront(v, true)
+}
+
+func (c *CycList) PushBack(v interface{}) {
+	c.Append(v)
+}
+
+
+func (l *DoubleList) PushFront(v interface{}) {
+	l.pushFront(v, false)
+}
+
+func (l *DoubleList) PushBack(v interface{}) {
+	l.Append(v)
+}
+
+//	Removes and returns the first element of the list
+func (l *DoubleList) PopFront() (r interface{}, ok bool) {
+	if r, ok = l.PeekFront(); ok {
+		l.Remove(l.start)
+	}
+	return
+}
+
+//	Removes and returns the last element of the list
+func (l *DoubleList) PopBack() (r interface{}, ok bool) {
+	if r, ok = l.PeekBack(); ok {
+		l.Remove(l.end)
+	}
+	return
+}
+
+
+func (c *DoubleLoop) PushFront(v interface{}) {
+	c.pushFront(v, true)
+}
+
+func (c *DoubleLoop) PushBack(v interface{}) {
+	c.Append(v)
+}
+
+//	Removes and returns the first element of the loop
+func (c *DoubleLoop) PopFront() (r interface{}, ok bool) {
+	if r, ok = c.PeekFront(); ok {
+		c.Remove(c.start)
+	}
+	return
+}
+
+//	Removes and returns the last element of the loop
+func (c *DoubleLoop) PopBack() (r interface{}, ok bool) {
+	if r, ok = c.PeekBack(); ok {
+		c.Remove(c.end)
+	}
+	return
+}
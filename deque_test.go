@@ -0,0 +1,244 @@
+package lists
+
+import "testing"
+
+func TestHead(t *testing.T) {
+	switch {
+	case List().Head() != nil:			t.Fatalf("Head() of an empty list should be nil")
+	case List(0, 1).Head() != 0:		t.Fatalf("Head() should be 0 not %v", List(0, 1).Head())
+	case Loop(2, 3).Head() != 2:		t.Fatalf("Head() should be 2 not %v", Loop(2, 3).Head())
+	}
+}
+
+func TestPeek(t *testing.T) {
+	ConfirmPeek := func(l *LinearList, front, back interface{}, ok bool) {
+		if v, k := l.PeekFront(); v != front || k != ok {
+			t.Fatalf("%v.PeekFront() should be %v, %v not %v, %v", l, front, ok, v, k)
+		}
+		if v, k := l.PeekBack(); v != back || k != ok {
+			t.Fatalf("%v.PeekBack() should be %v, %v not %v, %v", l, back, ok, v, k)
+		}
+	}
+	ConfirmPeek(List(), nil, nil, false)
+	ConfirmPeek(List(nil), nil, nil, true)
+	ConfirmPeek(List(0), 0, 0, true)
+	ConfirmPeek(List(0, 1, 2), 0, 2, true)
+}
+
+func TestLinearListDeque(t *testing.T) {
+	l := List()
+	l.Debug(true)
+	l.PushBack(1)
+	l.PushFront(0)
+	l.PushBack(2)
+	if !l.Equal(List(0, 1, 2)) {
+		t.Fatalf("pushing should give (0 1 2) not %v", l)
+	}
+
+	ConfirmPop := func(pop func() (interface{}, bool), name string, r interface{}, ok bool, rest *LinearList) {
+		if v, k := pop(); v != r || k != ok {
+			t.Fatalf("%v() should return %v, %v not %v, %v", name, r, ok, v, k)
+		}
+		if !l.Equal(rest) {
+			t.Fatalf("%v() should leave %v not %v", name, rest, l)
+		}
+	}
+	ConfirmPop(l.PopBack, "PopBack", 2, true, List(0, 1))
+	ConfirmPop(l.PopFront, "PopFront", 0, true, List(1))
+	ConfirmPop(l.PopBack, "PopBack", 1, true, List())
+	ConfirmPop(l.PopBack, "PopBack", nil, false, List())
+	ConfirmPop(l.PopFront, "PopFront", nil, false, List())
+
+	l.PushFront(0)
+	if l.Append(1); !l.Equal(List(0, 1)) || l.End().Content() != 1 {
+		t.Fatalf("pushing onto an emptied list should give (0 1) not %v", l)
+	}
+}
+
+func TestPopBackPredecessors(t *testing.T) {
+	l := List(0, 1, 2, 3, 4, 5, 6, 7)
+	if v, _ := l.PopBack(); v != 7 || len(l.predecessors) != 6 {
+		t.Fatalf("PopBack() should remember the nodes it passed, not %v", len(l.predecessors))
+	}
+	if v, _ := l.PopBack(); v != 6 || len(l.predecessors) != 5 {
+		t.Fatalf("PopBack() should reuse the nodes it remembered, not %v", len(l.predecessors))
+	}
+	l.Append(10)
+	if v, _ := l.PopBack(); v != 10 || !l.Equal(List(0, 1, 2, 3, 4, 5)) {
+		t.Fatalf("PopBack() after Append() should give 10 leaving (0 1 2 3 4 5) not %v", l)
+	}
+	l.Delete(2, 2)
+	if len(l.predecessors) > 2 {
+		t.Fatalf("Delete() should forget the nodes it moved, not %v", len(l.predecessors))
+	}
+	if v, _ := l.PopBack(); v != 5 || !l.Equal(List(0, 1, 3, 4)) {
+		t.Fatalf("PopBack() after Delete() should give 5 leaving (0 1 3 4) not %v", l)
+	}
+	l.PushFront(-1)
+	for _, r := range []interface{}{ 4, 3, 1, 0, -1 } {
+		if v, ok := l.PopBack(); v != r || !ok {
+			t.Fatalf("PopBack() should give %v not %v", r, v)
+		}
+	}
+	if l.Len() != 0 || l.start != nil || l.end != nil {
+		t.Fatalf("PopBack() should empty the list not leave %v", l)
+	}
+}
+
+func TestCycListDeque(t *testing.T) {
+	c := Loop()
+	c.Debug(true)
+	c.PushFront(1)
+	c.PushFront(0)
+	c.PushBack(2)
+	if !c.Equal(Loop(0, 1, 2)) || c.At(3) != 0 {
+		t.Fatalf("pushing should give a closed loop (0 1 2) not %v", c)
+	}
+	if v, ok := c.PopBack(); v != 2 || !ok || c.At(2) != 0 {
+		t.Fatalf("PopBack() should give 2 leaving a closed loop not %v", c)
+	}
+	if v, ok := c.PopFront(); v != 0 || !ok || c.At(1) != 1 {
+		t.Fatalf("PopFront() should give 0 leaving a closed loop not %v", c)
+	}
+	if v, ok := c.PopBack(); v != 1 || !ok || c.Len() != 0 {
+		t.Fatalf("PopBack() should empty the loop not leave %v", c)
+	}
+	if _, ok := c.PopFront(); ok {
+		t.Fatalf("PopFront() should fail on an empty loop")
+	}
+}
+
+func TestPopBackMixedEnds(t *testing.T) {
+	walked := 0
+	PopBack := func(l *LinearList, r interface{}) {
+		n := len(l.predecessors)
+		if v, ok := l.PopBack(); v != r || !ok {
+			t.Fatalf("PopBack() should give %v not %v", r, v)
+		}
+		if m := len(l.predecessors) + 1 - n; m > 0 {
+			walked += m
+		}
+	}
+	l := List()
+	for i := 0; i < 1000; i++ {
+		l.PushBack(i)
+	}
+	for i := 0; i < 500; i++ {
+		l.PushFront(-1)
+		PopBack(l, 999 - i)
+	}
+	for l.Len() > 1 {
+		l.PopFront()
+		PopBack(l, l.End().Content())
+	}
+	if walked > 2000 {
+		t.Fatalf("PopBack() should walk each node at most once after it is added, not %v steps for 1500 elements", walked)
+	}
+	if l.PopFront(); l.predecessors != nil || l.predecessorBase != 0 {
+		t.Fatalf("an emptied list should remember no predecessors, not %v from %v", len(l.predecessors), l.predecessorBase)
+	}
+}
+
+func TestPopBackAfterRemovalAtEnd(t *testing.T) {
+	l := List(0, 1, 2)
+	l.Debug(true)
+	l.PopBack()
+	l.Delete(1, 1)
+	l.PushFront(9)
+	if v, ok := l.PopBack(); v != 0 || !ok || !l.Equal(List(9)) {
+		t.Fatalf("PopBack() should give 0 leaving (9) not %v, %v leaving %v", v, ok, l)
+	}
+
+	c := Loop(0, 1, 2)
+	c.Debug(true)
+	c.PopBack()
+	c.Cut(1, 1)
+	c.PushFront(9)
+	if v, ok := c.PopBack(); v != 0 || !ok || !c.Equal(Loop(9)) {
+		t.Fatalf("PopBack() should give 0 leaving (9 ...) not %v, %v leaving %v", v, ok, c)
+	}
+}
+
+func TestPopBackAfterPushFront(t *testing.T) {
+	l := List(0, 1, 2, 3)
+	l.Debug(true)
+	l.PopBack()
+	l.PushFront(-1)
+	l.PushFront(-2)
+	l.Insert(1, 10)
+	for _, r := range []interface{}{ 2, 1, 0, -1, 10, -2 } {
+		if v, ok := l.PopBack(); v != r || !ok {
+			t.Fatalf("PopBack() should give %v not %v", r, v)
+		}
+	}
+}
+
+func TestDoubleListDeque(t *testing.T) {
+	l := DList()
+	l.Debug(true)
+	l.PushBack(1)
+	l.PushFront(0)
+	l.PushBack(2)
+	l.PushBack(3)
+	confirmDoubleLinks(t, l.ListHeader)
+	if v, ok := l.PopFront(); v != 0 || !ok || !l.Equal(DList(1, 2, 3)) {
+		t.Fatalf("PopFront() should give 0 leaving (1 2 3) not %v", l)
+	}
+	confirmDoubleLinks(t, l.ListHeader)
+	if !l.Remove(l.Start()) || !l.Equal(DList(2, 3)) {
+		t.Fatalf("Remove() of the start after PopFront() should leave (2 3) not %v", l)
+	}
+	confirmDoubleLinks(t, l.ListHeader)
+	if v, ok := l.PopBack(); v != 3 || !ok || !l.Equal(DList(2)) {
+		t.Fatalf("PopBack() should give 3 leaving (2) not %v", l)
+	}
+	if l.PushFront(1); !l.Remove(l.End()) || !l.Equal(DList(1)) {
+		t.Fatalf("Remove() of the end after PushFront() should leave (1) not %v", l)
+	}
+	confirmDoubleLinks(t, l.ListHeader)
+	if v, ok := l.PopBack(); v != 1 || !ok || l.Len() != 0 || l.Start() != nil || l.End() != nil {
+		t.Fatalf("PopBack() should empty the list not leave %v", l)
+	}
+	if _, ok := l.PopFront(); ok {
+		t.Fatalf("PopFront() should fail on an empty list")
+	}
+	if e := l.Validate(); e != nil {
+		t.Fatalf("an emptied list should be valid: %v", e)
+	}
+}
+
+func TestDoubleLoopDeque(t *testing.T) {
+	c := DLoop()
+	c.Debug(true)
+	c.PushFront(1)
+	c.PushFront(0)
+	c.PushBack(2)
+	confirmDoubleLinks(t, c.ListHeader)
+	if !c.Equal(DLoop(0, 1, 2)) || c.At(3) != 0 {
+		t.Fatalf("pushing should give a closed loop (0 1 2) not %v", c)
+	}
+	if v, ok := c.PopFront(); v != 0 || !ok || c.At(2) != 1 {
+		t.Fatalf("PopFront() should give 0 leaving a closed loop not %v", c)
+	}
+	confirmDoubleLinks(t, c.ListHeader)
+	if v, ok := c.PopBack(); v != 2 || !ok || c.At(1) != 1 {
+		t.Fatalf("PopBack() should give 2 leaving a closed loop not %v", c)
+	}
+	confirmDoubleLinks(t, c.ListHeader)
+	if v, ok := c.PopBack(); v != 1 || !ok || c.Len() != 0 {
+		t.Fatalf("PopBack() should empty the loop not leave %v", c)
+	}
+	if _, ok := c.PopFront(); ok {
+		t.Fatalf("PopFront() should fail on an empty loop")
+	}
+}
+
+func TestTailUnlinksPrevious(t *testing.T) {
+	l := DList(0, 1, 2)
+	h := l.ListHeader
+	h.Tail()
+	confirmDoubleLinks(t, h)
+	if e := h.Validate(); e != nil {
+		t.Fatalf("Tail() should leave a valid list: %v", e)
+	}
+}
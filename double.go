@@ -0,0 +1,490 @@
+package lists
+
+import (
+	"iter"
+	"reflect"
+	"github.com/feyeleanor/chain"
+)
+
+/*
+	A DoubleNode is a list node which links to both the following and the preceding node.
+	Linking a node as the successor of a DoubleNode also makes the DoubleNode its predecessor,
+	so code written in terms of NEXT_NODE links keeps the reverse links consistent.
+*/
+type DoubleNode struct {
+	Head		interface{}
+	next		*DoubleNode
+	previous	*DoubleNode
+}
+
+func asDoubleNode(n chain.Node) (r *DoubleNode, ok bool) {
+	if n == nil {
+		ok = true
+	} else {
+		r, ok = n.(*DoubleNode)
+	}
+	return
+}
+
+//	Converts a possibly nil *DoubleNode to a chain.Node without creating a non-nil interface holding a nil pointer
+func (n *DoubleNode) node() (r chain.Node) {
+	if n != nil {
+		r = n
+	}
+	return
+}
+
+func (n *DoubleNode) Content() (r interface{}) {
+	if n != nil {
+		r = n.Head
+	}
+	return
+}
+
+func (n *DoubleNode) MoveTo(i int) chain.Node {
+	for ; n != nil && i > 0; i-- {
+		n = n.next
+	}
+	for ; n != nil && i < 0; i++ {
+		n = n.previous
+	}
+	return n.node()
+}
+
+func (n *DoubleNode) Link(i int, l chain.Node) (ok bool) {
+	if x, is := asDoubleNode(l); is && n != nil {
+		switch i {
+		case chain.PREVIOUS_NODE:	n.previous = x
+									if x != nil {
+										x.next = n
+									}
+									ok = true
+
+		case chain.CURRENT_NODE:	if x != nil {
+										n.Head = x.Head
+										n.Link(chain.NEXT_NODE, x.next.node())
+										ok = true
+									}
+
+		case chain.NEXT_NODE:		n.next = x
+									if x != nil {
+										x.previous = n
+									}
+									ok = true
+		}
+	}
+	return
+}
+
+func (n *DoubleNode) Set(i int, v interface{}) (ok bool) {
+	if n != nil && i > chain.PREVIOUS_NODE {
+		for ; i > 0; i-- {
+			if n.next == nil {
+				n.Link(chain.NEXT_NODE, &DoubleNode{})
+			}
+			n = n.next
+		}
+		n.Head = v
+		ok = true
+	}
+	return
+}
+
+//	Compares the content of the node with either the content of another node or a plain value.
+//	Content which cannot be compared with ==, such as a slice, is never equal.
+func (n *DoubleNode) Equal(o interface{}) (r bool) {
+	if n != nil {
+		if x, ok := o.(chain.Node); ok {
+			o = x.Content()
+		}
+		if v, ok := n.Head.(Equatable); ok {
+			r = v.Equal(o)
+		} else {
+			r = (n.Head == nil || reflect.ValueOf(n.Head).Comparable()) && n.Head == o
+		}
+	}
+	return
+}
+
+
+//	Restores the PREVIOUS_NODE links of all nodes in a list by walking it from the start.
+func (l *ListHeader) relink(closed bool) {
+	var previous chain.Node
+	if closed {
+		previous = l.end
+	}
+	l.eachNode(func(i int, n chain.Node) {
+		n.Link(chain.PREVIOUS_NODE, previous)
+		previous = n
+	})
+}
+
+/*
+	A reversed list is traversed from its start by following the PREVIOUS_NODE links of its nodes,
+	which is how DoubleList and DoubleLoop reverse in constant time. The methods which read a list
+	follow its links with next(), previous() and move(), whilst those which relink nodes call
+	straighten() first so that they can continue to work in terms of NEXT_NODE links.
+*/
+
+//	Returns the node following n in the order of traversal of the list
+func (l ListHeader) next(n chain.Node) (r chain.Node) {
+	if l.reversed {
+		r = chain.Previous(n)
+	} else {
+		r = chain.Next(n)
+	}
+	return
+}
+
+//	Returns the node preceding n in the order of traversal of the list
+func (l ListHeader) previous(n chain.Node) (r chain.Node) {
+	if l.reversed {
+		r = chain.Next(n)
+	} else {
+		r = chain.Previous(n)
+	}
+	return
+}
+
+//	Moves i nodes from n in the order of traversal of the list, or against it when i is negative
+func (l ListHeader) move(n chain.Node, i int) chain.Node {
+	if l.reversed {
+		i = -i
+	}
+	return n.MoveTo(i)
+}
+
+//	Exchanges the NEXT_NODE and PREVIOUS_NODE links of every node of a reversed list so that the NEXT_NODE links
+//	once more follow its order of traversal. This takes O(n) time, which is paid once for each reversal.
+func (l *ListHeader) straighten() {
+	if l.reversed {
+		x, _ := l.start.(*DoubleNode)
+		for i := l.length; i > 0; i-- {
+			x.next, x.previous = x.previous, x.next
+			x = x.next
+		}
+		l.reversed = false
+	}
+}
+
+//	Reports whether a list is traversed against the NEXT_NODE links of its nodes
+func isReversed(v interface{}) (r bool) {
+	switch v := v.(type) {
+	case ListHeader:	r = v.reversed
+	case DoubleList:	r = v.reversed
+	case DoubleLoop:	r = v.reversed
+	default:			if h := headerOf(v); h != nil {
+							r = h.reversed
+						}
+	}
+	return
+}
+
+//	Reverses the order of traversal of the list in O(1) time by exchanging its start and end
+func (l *ListHeader) reverseLinks() {
+	l.start, l.end = l.end, l.start
+	l.reversed = !l.reversed
+	l.invalidate(0)
+}
+
+//	Locates the node at a given offset, walking from whichever end of the list is closer.
+func (l ListHeader) nearestNode(i int) (n chain.Node) {
+	switch {
+	case i < 0 || i >= l.length:
+	case i <= l.length / 2:			n = l.move(l.start, i)
+	default:						n = l.move(l.end, i - l.length + 1)
+	}
+	return
+}
+
+func (l ListHeader) backward() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		n := l.end
+		for i := l.length - 1; i > -1; i-- {
+			if !yield(i, n.Content()) {
+				return
+			}
+			n = l.previous(n)
+		}
+	}
+}
+
+
+/*
+	A DoubleList is a finitely-terminated list in which each node links to both its successor
+	and its predecessor. The first node has no predecessor and the last node has no successor.
+
+	Because nodes know their predecessor a node can be removed in constant time and the list
+	can be traversed from either end.
+*/
+
+//	A declarative method for building DoubleLists
+func DList(items... interface{}) (l *DoubleList) {
+	l = NewDoubleList()
+	l.Concatenate(items)
+	return
+}
+
+type DoubleList struct {
+	ListHeader
+}
+
+func NewDoubleList() *DoubleList {
+	return &DoubleList{ NewListHeader(&DoubleNode{}) }
+}
+
+func (l DoubleList) Clone() *DoubleList {
+	return &DoubleList{ *l.ListHeader.Clone() }
+}
+
+//	Determines if another object is equivalent to the DoubleList
+func (l DoubleList) Equal(o interface{}) (r bool) {
+	switch o := o.(type) {
+	case *DoubleList:	r = o != nil && l.ListHeader.Equal(o.ListHeader)
+	case DoubleList:	r = l.ListHeader.Equal(o.ListHeader)
+	}
+	return
+}
+
+//	Return the value stored at the given offset from the start of the list
+func (l DoubleList) At(i int) (r interface{}) {
+	if n := l.nearestNode(i); n != nil {
+		r = n.Content()
+	}
+	return
+}
+
+//	Set the value stored at the given offset from the start of the list
+func (l DoubleList) Set(i int, v interface{}) {
+	if n := l.nearestNode(i); n != nil {
+		n.Set(chain.CURRENT_NODE, v)
+	}
+}
+
+//	Returns an iterator over the index and value of each element, following the links from the end of the list.
+func (l DoubleList) Backward() iter.Seq2[int, interface{}] {
+	return l.backward()
+}
+
+//	Removes a node belonging to the list in constant time.
+//	The caller is responsible for ensuring that the node is part of this list.
+func (l *DoubleList) Remove(n chain.Node) (ok bool) {
+	if x, is := n.(*DoubleNode); is && x != nil && l.length > 0 {
+		l.straighten()
+		previous, next := x.previous, x.next
+		if previous == nil {
+			l.start = next.node()
+		} else {
+			previous.next = next
+		}
+
+		if next == nil {
+			l.end = previous.node()
+		} else {
+			next.previous = previous
+		}
+		x.next, x.previous = nil, nil
+		l.length--
+		l.invalidate(0)
+		ok = true
+	}
+	return
+}
+
+//	Removes all elements in the range from the list.
+func (l *DoubleList) Delete(from, to int) {
+	if l != nil && l.EnforceBounds(&from, &to) {
+		l.straighten()
+		first := l.nearestNode(from).(*DoubleNode)
+		last := l.nearestNode(to).(*DoubleNode)
+		if first.previous == nil {
+			l.start = last.next.node()
+		} else {
+			first.previous.Link(chain.NEXT_NODE, last.next.node())
+		}
+
+		if last.next == nil {
+			l.end = first.previous.node()
+		} else {
+			last.next.Link(chain.PREVIOUS_NODE, first.previous.node())
+		}
+		first.previous, last.next = nil, nil
+		l.length -= to - from + 1
+		l.invalidate(from)
+	}
+}
+
+//	Insert an item into the list at the given location.
+func (l *DoubleList) Insert(i int, o interface{}) {
+	if l != nil && i > -1 && i <= l.length {
+		if i == l.length {
+			l.Append(o)
+		} else {
+			l.straighten()
+			n := l.NewListNode(o)
+			x := l.nearestNode(i)
+			if previous := chain.Previous(x); previous == nil {
+				l.start = n
+			} else {
+				previous.Link(chain.NEXT_NODE, n)
+			}
+			n.Link(chain.NEXT_NODE, x)
+			l.length++
+			l.invalidate(i)
+		}
+	}
+}
+
+//	Reverses the order in which elements of the list are traversed in O(1) time, after which the list follows
+//	the PREVIOUS_NODE links of its nodes. Reading and iterating over the list respect its direction, whilst the
+//	first operation to relink its nodes exchanges their links in O(n) time. Nodes reached through Start() and
+//	End() are the ends of the reversed list, but chain.Next follows their original links until then.
+func (l *DoubleList) Reverse() {
+	if l != nil {
+		l.reverseLinks()
+	}
+}
+
+func (l *DoubleList) Tail() {
+	if l.Remove(l.start); l.length == 0 {
+		l.Erase()
+	}
+}
+
+func (l *DoubleList) Flatten() {
+	l.ListHeader.Flatten()
+	l.relink(false)
+}
+
+
+/*
+	A DoubleLoop is a circular list in which each node links to both its successor and
+	its predecessor, so that the loop can be traversed and rotated in either direction.
+*/
+
+//	A declarative method for building DoubleLoops
+func DLoop(items... interface{}) (c *DoubleLoop) {
+	c = NewDoubleLoop()
+	c.Concatenate(items)
+	return
+}
+
+type DoubleLoop struct {
+	ListHeader
+}
+
+func NewDoubleLoop() *DoubleLoop {
+	return &DoubleLoop{ NewListHeader(&DoubleNode{}) }
+}
+
+func (c DoubleLoop) Clone() (r *DoubleLoop) {
+	r = &DoubleLoop{ *c.ListHeader.Clone() }
+	if r.end != nil {
+		r.end.Link(chain.NEXT_NODE, r.start)
+	}
+	return
+}
+
+//	Writes the loop in the notation read by Parse(), marking it as a loop with a final ... even when it is empty
+func (c DoubleLoop) String() (t string) {
+	if t = "(...)"; c.length > 0 {
+		t = c.ListHeader.String()
+	}
+	return
+}
+
+//	Determines if another object is equivalent to the DoubleLoop
+func (c DoubleLoop) Equal(o interface{}) (r bool) {
+	switch o := o.(type) {
+	case *DoubleLoop:	r = o != nil && c.ListHeader.Equal(o.ListHeader)
+	case DoubleLoop:	r = c.ListHeader.Equal(o.ListHeader)
+	}
+	return
+}
+
+func (c DoubleLoop) index(i int) int {
+	return cyclicIndex(i, c.length)
+}
+
+//	Return the value stored at the given offset from the start of the loop
+func (c DoubleLoop) At(i int) (r interface{}) {
+	if n := c.nearestNode(c.index(i)); n != nil {
+		r = n.Content()
+	}
+	return
+}
+
+//	Set the value stored at the given offset from the start of the loop
+func (c DoubleLoop) Set(i int, v interface{}) {
+	if n := c.nearestNode(c.index(i)); n != nil {
+		n.Set(chain.CURRENT_NODE, v)
+	}
+}
+
+//	Returns an iterator over the index and value of each element, following the links from the end of the loop.
+func (c DoubleLoop) Backward() iter.Seq2[int, interface{}] {
+	return c.backward()
+}
+
+func (c *DoubleLoop) Append(v interface{}) {
+	c.straighten()
+	if c.appendValue(v); c.end == c.start {
+		c.end.Link(chain.NEXT_NODE, c.start)
+	}
+	c.check()
+}
+
+func (c *DoubleLoop) Concatenate(i interface{}) {
+	c.straighten()
+	if c.concatenate(i); c.end != nil {
+		c.end.Link(chain.NEXT_NODE, c.start)
+	}
+	c.check()
+}
+
+//	Rotates the loop so that the element at the given offset becomes its start, walking in whichever direction is shorter.
+func (c *DoubleLoop) Rotate(i int) {
+	if c != nil && c.end != nil {
+		c.start = c.nearestNode(c.index(i))
+		c.end = c.previous(c.start)
+		c.invalidate(0)
+	}
+}
+
+//	Removes a node belonging to the loop in constant time.
+//	The caller is responsible for ensuring that the node is part of this loop.
+func (c *DoubleLoop) Remove(n chain.Node) (ok bool) {
+	if x, is := n.(*DoubleNode); is && x != nil && c.length > 0 {
+		c.straighten()
+		if c.length == 1 {
+			c.Erase()
+		} else {
+			x.previous.Link(chain.NEXT_NODE, x.next)
+			if n == c.start {
+				c.start = x.next
+			}
+			if n == c.end {
+				c.end = x.previous
+			}
+			c.length--
+			c.invalidate(0)
+		}
+		x.next, x.previous = nil, nil
+		ok = true
+	}
+	return
+}
+
+//	Reverses the order in which elements of the loop are traversed in O(1) time, with the same caveats as DoubleList.Reverse
+func (c *DoubleLoop) Reverse() {
+	if c != nil {
+		c.reverseLinks()
+	}
+}
+
+func (c *DoubleLoop) Flatten() {
+	if c.ListHeader.Flatten(); c.end != nil {
+		c.end.Link(chain.NEXT_NODE, c.start)
+		c.relink(true)
+	}
+}
@@ -0,0 +1,397 @@
+package lists
+
+import "github.com/feyeleanor/chain"
+import "testing"
+
+//	Checks that each node is preceded by the node before it in the order of traversal, which for a reversed list is its successor
+func confirmDoubleLinks(t *testing.T, l ListHeader) {
+	var previous chain.Node
+	if l.length > 0 && l.next(l.end) == l.start {
+		previous = l.end
+	}
+	l.eachNode(func(i int, n chain.Node) {
+		if p := l.previous(n); p != previous {
+			t.Fatalf("%v: node %v should be preceded by %v but is preceded by %v", l, i, previous, p)
+		}
+		previous = n
+	})
+	if previous != l.end {
+		t.Fatalf("%v: end of list should be %v but is %v", l, previous, l.end)
+	}
+}
+
+func TestDoubleListString(t *testing.T) {
+	ConfirmFormat := func(l *DoubleList, x string) {
+		if s := l.String(); s != x {
+			t.Fatalf("'%v' erroneously serialised as '%v'", x, s)
+		}
+	}
+	ConfirmFormat(DList(), "()")
+	ConfirmFormat(DList(0), "(0)")
+	ConfirmFormat(DList(0, nil), "(0 nil)")
+	ConfirmFormat(DList(1, DList(0, nil)), "(1 (0 nil))")
+}
+
+func TestDoubleListAt(t *testing.T) {
+	ConfirmAt := func(l *DoubleList, i int, v interface{}) {
+		if x := l.At(i); x != v {
+			t.Fatalf("%v.At(%v) should be %v but is %v", l, i, v, x)
+		}
+	}
+	l := DList(10, 11, 12, 13, 14, 15, 16)
+	ConfirmAt(l, -1, nil)
+	ConfirmAt(l, 0, 10)
+	ConfirmAt(l, 1, 11)
+	ConfirmAt(l, 3, 13)
+	ConfirmAt(l, 5, 15)
+	ConfirmAt(l, 6, 16)
+	ConfirmAt(l, 7, nil)
+}
+
+func TestDoubleListEqual(t *testing.T) {
+	switch {
+	case !DList(0, 1, 2).Equal(DList(0, 1, 2)):		t.Fatalf("%v should equal %v", DList(0, 1, 2), DList(0, 1, 2))
+	case DList(0, 1, 2).Equal(DList(0, 2, 1)):		t.Fatalf("%v should not equal %v", DList(0, 1, 2), DList(0, 2, 1))
+	case !DList(DList(1)).Equal(DList(DList(1))):	t.Fatalf("%v should equal %v", DList(DList(1)), DList(DList(1)))
+	}
+}
+
+func TestDoubleNodeEqual(t *testing.T) {
+	type holder struct { v interface{} }
+	ConfirmEqual := func(n *DoubleNode, o interface{}, r bool) {
+		if x := n.Equal(o); x != r {
+			t.Fatalf("%v.Equal(%v) should be %v", n.Head, o, r)
+		}
+	}
+	ConfirmEqual(&DoubleNode{ Head: 1 }, 1, true)
+	ConfirmEqual(&DoubleNode{ Head: 1 }, &DoubleNode{ Head: 1 }, true)
+	ConfirmEqual(&DoubleNode{ Head: 1 }, 2, false)
+	ConfirmEqual(&DoubleNode{}, nil, true)
+	ConfirmEqual(&DoubleNode{ Head: []int{ 1 } }, []int{ 1 }, false)
+	ConfirmEqual(&DoubleNode{ Head: holder{ []int{ 1 } } }, holder{ []int{ 1 } }, false)
+	ConfirmEqual(&DoubleNode{ Head: holder{ 1 } }, holder{ 1 }, true)
+	ConfirmEqual(nil, 1, false)
+}
+
+func TestDoubleListBackward(t *testing.T) {
+	l := DList(0, 1, 2, 3, 4)
+	count := l.Len()
+	for i, v := range l.Backward() {
+		count--
+		if i != count || v != count {
+			t.Fatalf("element %v erroneously reported as %v: %v", count, i, v)
+		}
+	}
+	if count != 0 {
+		t.Fatalf("backward iteration should visit %v elements", l.Len())
+	}
+}
+
+func TestDoubleListReverse(t *testing.T) {
+	ConfirmReverse := func(l, r *DoubleList) {
+		l.Reverse()
+		if !l.Equal(r) {
+			t.Fatalf("'%v' should be '%v'", l, r)
+		}
+		confirmDoubleLinks(t, l.ListHeader)
+	}
+	ConfirmReverse(DList(), DList())
+	ConfirmReverse(DList(1), DList(1))
+	ConfirmReverse(DList(1, 2), DList(2, 1))
+	ConfirmReverse(DList(1, 2, 3, 4), DList(4, 3, 2, 1))
+}
+
+func TestDoubleListReverseInPlace(t *testing.T) {
+	l := DList(1, 2, 3, 4, 5)
+	start, end := l.start.(*DoubleNode), l.end.(*DoubleNode)
+	l.Reverse()
+	switch {
+	case start.next == nil || start.previous != nil:	t.Fatalf("Reverse() should not relink the nodes")
+	case l.start != end || l.end != start:				t.Fatalf("Reverse() should exchange the start and end")
+	case l.At(0) != 5 || l.At(3) != 2 || l.At(4) != 1:	t.Fatalf("%v should read as (5 4 3 2 1)", l.Compact())
+	case l.Validate() != nil:							t.Fatalf("Validate() failed: %v", l.Validate())
+	}
+
+	l.Set(1, 40)
+	x := []interface{}{}
+	for _, v := range l.Backward() {
+		x = append(x, v)
+	}
+	if r := DList(1, 2, 3, 40, 5); !r.Equal(DList(x...)) {
+		t.Fatalf("Backward() should give %v not %v", r, x)
+	}
+	if s := l.String(); s != "(5 40 3 2 1)" {
+		t.Fatalf("String() should be (5 40 3 2 1) not %v", s)
+	}
+
+	l.Reverse()
+	if r := DList(1, 2, 3, 40, 5); !l.Equal(r) || start.previous != nil {
+		t.Fatalf("reversing twice should give %v with the original links not %v", r, l)
+	}
+}
+
+func TestDoubleListEditReversed(t *testing.T) {
+	ConfirmEdit := func(name string, f func(l *DoubleList), r *DoubleList) {
+		l := DList(1, 2, 3, 4)
+		l.Debug(true)
+		l.Reverse()
+		f(l)
+		if !l.Equal(r) {
+			t.Fatalf("%v on a reversed list should give %v not %v", name, r, l)
+		}
+		confirmDoubleLinks(t, l.ListHeader)
+	}
+	ConfirmEdit("Append(0)", func(l *DoubleList) { l.Append(0) }, DList(4, 3, 2, 1, 0))
+	ConfirmEdit("Insert(1, 0)", func(l *DoubleList) { l.Insert(1, 0) }, DList(4, 0, 3, 2, 1))
+	ConfirmEdit("Delete(1, 2)", func(l *DoubleList) { l.Delete(1, 2) }, DList(4, 1))
+	ConfirmEdit("Tail()", func(l *DoubleList) { l.Tail() }, DList(3, 2, 1))
+	ConfirmEdit("Expand(1, 1)", func(l *DoubleList) { l.Expand(1, 1) }, DList(4, nil, 3, 2, 1))
+	ConfirmEdit("PushFront(5)", func(l *DoubleList) { l.PushFront(5) }, DList(5, 4, 3, 2, 1))
+	ConfirmEdit("PopBack()", func(l *DoubleList) { l.PopBack() }, DList(4, 3, 2))
+	ConfirmEdit("SortedInsert(0)", func(l *DoubleList) {
+		l.SortedInsert(0, func(a, b interface{}) bool { return a.(int) > b.(int) })
+	}, DList(4, 3, 2, 1, 0))
+	ConfirmEdit("Sort()", func(l *DoubleList) {
+		l.Sort(func(a, b interface{}) bool { return a.(int) < b.(int) })
+	}, DList(1, 2, 3, 4))
+	ConfirmEdit("Cursor(1).Remove()", func(l *DoubleList) { l.Cursor(1).Remove() }, DList(4, 2, 1))
+	ConfirmEdit("Reverse()", func(l *DoubleList) { l.Reverse() }, DList(1, 2, 3, 4))
+}
+
+func TestDoubleListFlattenReversed(t *testing.T) {
+	inner := DList(1, 2, 3)
+	inner.Reverse()
+	l := DList(0, inner, 4)
+	l.Flatten()
+	if r := DList(0, 3, 2, 1, 4); !l.Equal(r) {
+		t.Fatalf("Flatten() should give %v not %v", r, l)
+	}
+	confirmDoubleLinks(t, l.ListHeader)
+
+	inner = DList(1, 2, 3)
+	inner.Reverse()
+	l = DList(0, *inner, 4)
+	l.Flatten()
+	if r := DList(0, 3, 2, 1, 4); !l.Equal(r) {
+		t.Fatalf("Flatten() should give %v not %v", r, l)
+	}
+	confirmDoubleLinks(t, l.ListHeader)
+}
+
+func TestDoubleListRemove(t *testing.T) {
+	ConfirmRemove := func(l *DoubleList, i int, r *DoubleList) {
+		if !l.Remove(l.nearestNode(i)) {
+			t.Fatalf("Remove(%v) should succeed", i)
+		}
+		if !l.Equal(r) {
+			t.Fatalf("Remove(%v) should be '%v' and not '%v'", i, r, l)
+		}
+		confirmDoubleLinks(t, l.ListHeader)
+	}
+	ConfirmRemove(DList(0), 0, DList())
+	ConfirmRemove(DList(0, 1, 2), 0, DList(1, 2))
+	ConfirmRemove(DList(0, 1, 2), 1, DList(0, 2))
+	ConfirmRemove(DList(0, 1, 2), 2, DList(0, 1))
+
+	if DList().Remove(nil) {
+		t.Fatalf("Remove(nil) should fail")
+	}
+}
+
+func TestDoubleListDelete(t *testing.T) {
+	ConfirmDelete := func(l *DoubleList, from, to int, r *DoubleList) {
+		l.Delete(from, to)
+		switch {
+		case !l.Equal(r):			t.Fatalf("Delete(%v, %v) should be '%v' and not '%v'", from, to, r, l)
+		case l.Len() != r.Len():	t.Fatalf("Delete(%v, %v) length be '%v' and not '%v'", from, to, r.Len(), l.Len())
+		}
+		confirmDoubleLinks(t, l.ListHeader)
+	}
+	ConfirmDelete(DList(0, 1, 2, 3), -1, 0, DList(1, 2, 3))
+	ConfirmDelete(DList(0, 1, 2, 3), 0, -1, DList(0, 1, 2, 3))
+	ConfirmDelete(DList(0, 1, 2, 3), 0, 4, DList())
+	ConfirmDelete(DList(0, 1, 2, 3), 0, 1, DList(2, 3))
+	ConfirmDelete(DList(0, 1, 2, 3), 1, 2, DList(0, 3))
+	ConfirmDelete(DList(0, 1, 2, 3), 2, 3, DList(0, 1))
+	ConfirmDelete(DList(0, 1, 2, 3), 3, 3, DList(0, 1, 2))
+}
+
+func TestDoubleListInsert(t *testing.T) {
+	ConfirmInsert := func(l *DoubleList, i int, v interface{}, r *DoubleList) {
+		l.Insert(i, v)
+		if !r.Equal(l) {
+			t.Fatalf("Insert(%v, %v) should be %v but is %v", i, v, r, l)
+		}
+		confirmDoubleLinks(t, l.ListHeader)
+	}
+	ConfirmInsert(DList(), -1, 1, DList())
+	ConfirmInsert(DList(), 0, 1, DList(1))
+	ConfirmInsert(DList(0, 1, 2), 0, 3, DList(3, 0, 1, 2))
+	ConfirmInsert(DList(0, 1, 2), 1, 3, DList(0, 3, 1, 2))
+	ConfirmInsert(DList(0, 1, 2), 2, 3, DList(0, 1, 3, 2))
+	ConfirmInsert(DList(0, 1, 2), 3, 3, DList(0, 1, 2, 3))
+	ConfirmInsert(DList(0, 1, 2), 4, 3, DList(0, 1, 2))
+}
+
+func TestDoubleListTail(t *testing.T) {
+	ConfirmTail := func(l, r *DoubleList) {
+		l.Tail()
+		if !l.Equal(r) {
+			t.Fatalf("Tail should be '%v' but is '%v'", r, l)
+		}
+		confirmDoubleLinks(t, l.ListHeader)
+	}
+	ConfirmTail(DList(), DList())
+	ConfirmTail(DList(0), DList())
+	ConfirmTail(DList(0, 1, 2), DList(1, 2))
+}
+
+func TestDoubleListFlatten(t *testing.T) {
+	ConfirmFlatten := func(l, r *DoubleList) {
+		l.Flatten()
+		if !l.Equal(r) {
+			t.Fatalf("'%v' should be '%v'", l, r)
+		}
+		confirmDoubleLinks(t, l.ListHeader)
+	}
+	ConfirmFlatten(DList(1, DList(2, 3)), DList(1, 2, 3))
+	ConfirmFlatten(DList(DList(1, 2), 3), DList(1, 2, 3))
+	ConfirmFlatten(DList(1, List(2, DList(3, 4)), 5), DList(1, 2, 3, 4, 5))
+
+	l := List(1, DList(2, 3), 4)
+	l.Flatten()
+	if r := List(1, 2, 3, 4); !l.Equal(r) {
+		t.Fatalf("'%v' should be '%v'", l, r)
+	}
+}
+
+func TestDoubleListConcatenate(t *testing.T) {
+	l := List(0)
+	l.Concatenate(DList(1, 2))
+	if r := List(0, 1, 2); !l.Equal(r) {
+		t.Fatalf("%v should be %v", l, r)
+	}
+
+	d := DList(0)
+	d.Concatenate(List(1, 2))
+	if r := DList(0, 1, 2); !d.Equal(r) {
+		t.Fatalf("%v should be %v", d, r)
+	}
+	confirmDoubleLinks(t, d.ListHeader)
+}
+
+func TestDoubleLoopString(t *testing.T) {
+	ConfirmFormat := func(c *DoubleLoop, x string) {
+		if s := c.String(); s != x {
+			t.Fatalf("'%v' erroneously serialised as '%v'", x, s)
+		}
+	}
+	ConfirmFormat(DLoop(), "(...)")
+	ConfirmFormat(DLoop(0), "(0 ...)")
+	ConfirmFormat(DLoop(0, 1, 2), "(0 1 2 ...)")
+}
+
+func TestDoubleLoopAt(t *testing.T) {
+	ConfirmAt := func(c *DoubleLoop, i int, v interface{}) {
+		if x := c.At(i); x != v {
+			t.Fatalf("%v.At(%v) should be %v but is %v", c, i, v, x)
+		}
+	}
+	c := DLoop(10, 11, 12, 13, 14, 15, 16, 17, 18, 19)
+	ConfirmAt(c, -21, 19)
+	ConfirmAt(c, -10, 10)
+	ConfirmAt(c, -1, 19)
+	ConfirmAt(c, 0, 10)
+	ConfirmAt(c, 7, 17)
+	ConfirmAt(c, 10, 10)
+	ConfirmAt(c, 21, 11)
+}
+
+func TestDoubleLoopRotate(t *testing.T) {
+	ConfirmRotate := func(c *DoubleLoop, i int, r *DoubleLoop) {
+		c.Rotate(i)
+		if !c.Equal(r) {
+			t.Fatalf("%v should be %v", c, r)
+		}
+		confirmDoubleLinks(t, c.ListHeader)
+	}
+	ConfirmRotate(DLoop(), 1, DLoop())
+	ConfirmRotate(DLoop(0), 1, DLoop(0))
+	ConfirmRotate(DLoop(0, 1, 2, 3), 0, DLoop(0, 1, 2, 3))
+	ConfirmRotate(DLoop(0, 1, 2, 3), 1, DLoop(1, 2, 3, 0))
+	ConfirmRotate(DLoop(0, 1, 2, 3), 3, DLoop(3, 0, 1, 2))
+	ConfirmRotate(DLoop(0, 1, 2, 3), -1, DLoop(3, 0, 1, 2))
+	ConfirmRotate(DLoop(0, 1, 2, 3), -4, DLoop(0, 1, 2, 3))
+}
+
+func TestDoubleLoopReverse(t *testing.T) {
+	ConfirmReverse := func(c, r *DoubleLoop) {
+		c.Reverse()
+		if !c.Equal(r) {
+			t.Fatalf("%v should be %v", c, r)
+		}
+		confirmDoubleLinks(t, c.ListHeader)
+	}
+	ConfirmReverse(DLoop(1), DLoop(1))
+	ConfirmReverse(DLoop(1, 2), DLoop(2, 1))
+	ConfirmReverse(DLoop(1, 2, 3, 4), DLoop(4, 3, 2, 1))
+}
+
+func TestDoubleLoopEditReversed(t *testing.T) {
+	c := DLoop(1, 2, 3, 4)
+	c.Debug(true)
+	c.Reverse()
+	switch {
+	case c.At(4) != 4 || c.At(-1) != 1:		t.Fatalf("%v should cycle back to its start", c)
+	case c.String() != "(4 3 2 1 ...)":		t.Fatalf("String() should be (4 3 2 1 ...) not %v", c)
+	}
+	c.Rotate(1)
+	if r := DLoop(3, 2, 1, 4); !c.Equal(r) {
+		t.Fatalf("Rotate(1) should give %v not %v", r, c)
+	}
+	c.Append(0)
+	if r := DLoop(3, 2, 1, 4, 0); !c.Equal(r) {
+		t.Fatalf("Append(0) should give %v not %v", r, c)
+	}
+	confirmDoubleLinks(t, c.ListHeader)
+
+	c.Reverse()
+	c.Concatenate([]interface{}{})
+	if r := DLoop(0, 4, 1, 2, 3); !c.Equal(r) {
+		t.Fatalf("Concatenate() of nothing should leave %v not %v", r, c)
+	}
+	c.PopFront()
+	if r := DLoop(4, 1, 2, 3); !c.Equal(r) {
+		t.Fatalf("PopFront() should give %v not %v", r, c)
+	}
+	confirmDoubleLinks(t, c.ListHeader)
+}
+
+func TestDoubleLoopRemove(t *testing.T) {
+	ConfirmRemove := func(c *DoubleLoop, i int, r *DoubleLoop) {
+		if !c.Remove(c.nearestNode(i)) {
+			t.Fatalf("Remove(%v) should succeed", i)
+		}
+		if !c.Equal(r) {
+			t.Fatalf("Remove(%v) should be '%v' and not '%v'", i, r, c)
+		}
+		confirmDoubleLinks(t, c.ListHeader)
+	}
+	ConfirmRemove(DLoop(0), 0, DLoop())
+	ConfirmRemove(DLoop(0, 1, 2), 0, DLoop(1, 2))
+	ConfirmRemove(DLoop(0, 1, 2), 1, DLoop(0, 2))
+	ConfirmRemove(DLoop(0, 1, 2), 2, DLoop(0, 1))
+}
+
+func TestDoubleLoopBackward(t *testing.T) {
+	c := DLoop(0, 1, 2, 3)
+	c.Rotate(2)
+	r := []interface{}{ 1, 0, 3, 2 }
+	count := 0
+	for _, v := range c.Backward() {
+		if v != r[count] {
+			t.Fatalf("element %v erroneously reported as %v", count, v)
+		}
+		count++
+	}
+}
@@ -0,0 +1,175 @@
+package lists
+
+import "reflect"
+
+/*
+	Lists are compared element by element according to their contents, so lists built from
+	different node types are equal when they hold equal elements.
+
+	Equal compares nested lists of the same type recursively, Equatable elements with their
+	Equal method and any other elements with ==, falling back to reflect.DeepEqual for values
+	such as slices which cannot be compared with ==.
+
+	DeepEqual also compares nested Sequences of differing types by their contents, although a
+	loop is never equal to a list which is not a loop, and compares elements which are not
+	Equatable with reflect.DeepEqual. EqualFunc behaves as DeepEqual but compares elements
+	which are not themselves lists with a supplied function.
+
+	Lists which contain themselves are compared safely as a pair of lists which are already
+	under comparison is treated as equal.
+*/
+
+type comparison struct {
+	visited		map[[2]listKey]bool
+	deep		bool
+	leaf		func(a, b interface{}) bool
+}
+
+func newComparison(deep bool, leaf func(a, b interface{}) bool) *comparison {
+	return &comparison{ visited: make(map[[2]listKey]bool), deep: deep, leaf: leaf }
+}
+
+//	Compares two lists element by element
+func (c *comparison) lists(l, o *ListHeader) (r bool) {
+	if l.length == o.length {
+		k := [2]listKey{ l.key(), o.key() }
+		if c.visited[k] {
+			return true
+		}
+		c.visited[k] = true
+
+		r = true
+		n := l.start
+		x := o.start
+		for i := l.length; r && i > 0; i-- {
+			if r = c.values(n.Content(), x.Content()); r {
+				n = l.next(n)
+				x = o.next(x)
+			}
+		}
+	}
+	return
+}
+
+//	Compares two Sequences which are not both lists element by element
+func (c *comparison) sequences(a, b Sequence) (r bool) {
+	if r = a.Len() == b.Len(); r {
+		for i := a.Len() - 1; r && i > -1; i-- {
+			r = c.values(a.At(i), b.At(i))
+		}
+	}
+	return
+}
+
+func (c *comparison) values(a, b interface{}) (r bool) {
+	h, o := headerOf(a), headerOf(b)
+	switch {
+	case h != nil && o != nil && c.deep:	r = isLoop(a) == isLoop(b) && c.lists(h, o)
+	case h != nil && o != nil:				r = reflect.TypeOf(a) == reflect.TypeOf(b) && c.lists(h, o)
+	case c.deep:							x, xok := a.(Sequence)
+											y, yok := b.(Sequence)
+											if xok && yok {
+												r = c.sequences(x, y)
+											} else {
+												r = c.leaf(a, b)
+											}
+	default:								r = c.leaf(a, b)
+	}
+	return
+}
+
+func isLoop(v interface{}) (r bool) {
+	switch v.(type) {
+	case *CycList, *DoubleLoop:		r = true
+	}
+	return
+}
+
+//	Compares two elements with Equal when the first is Equatable, with == when it is comparable and otherwise with reflect.DeepEqual.
+//	A panic raised during the comparison, such as from comparing interfaces holding uncomparable values, means the elements differ.
+func equalValues(a, b interface{}) (r bool) {
+	defer func() {
+		if recover() != nil {
+			r = false
+		}
+	}()
+	switch t := reflect.TypeOf(a); {
+	case t == nil:
+		r = b == nil
+	case t.Implements(equatable):
+		r = a.(Equatable).Equal(b)
+	case t.Comparable():
+		r = a == b
+	default:
+		r = reflect.DeepEqual(a, b)
+	}
+	return
+}
+
+var equatable = reflect.TypeOf((*Equatable)(nil)).Elem()
+
+//	Compares two elements with Equal when the first is Equatable and otherwise with reflect.DeepEqual
+func deepValues(a, b interface{}) (r bool) {
+	defer func() {
+		if recover() != nil {
+			r = false
+		}
+	}()
+	if e, ok := a.(Equatable); ok {
+		r = e.Equal(b)
+	} else {
+		r = reflect.DeepEqual(a, b)
+	}
+	return
+}
+
+
+//	Determines whether o is a list or Sequence holding deeply equal elements, comparing nested Sequences by their contents
+func (l ListHeader) DeepEqual(o interface{}) bool {
+	return newComparison(true, deepValues).values(&l, o)
+}
+
+//	Determines whether o is a list or Sequence whose elements are equal under eq, comparing nested Sequences by their contents
+func (l ListHeader) EqualFunc(o interface{}, eq func(a, b interface{}) bool) bool {
+	return newComparison(true, eq).values(&l, o)
+}
+
+//	Determines whether o is a list or Sequence holding deeply equal elements, comparing nested Sequences by their contents
+func (l LinearList) DeepEqual(o interface{}) bool {
+	return newComparison(true, deepValues).values(&l, o)
+}
+
+//	Determines whether o is a list or Sequence whose elements are equal under eq, comparing nested Sequences by their contents
+func (l LinearList) EqualFunc(o interface{}, eq func(a, b interface{}) bool) bool {
+	return newComparison(true, eq).values(&l, o)
+}
+
+//	Determines whether o is a loop holding deeply equal elements, comparing nested Sequences by their contents
+func (c CycList) DeepEqual(o interface{}) bool {
+	return newComparison(true, deepValues).values(&c, o)
+}
+
+//	Determines whether o is a loop whose elements are equal under eq, comparing nested Sequences by their contents
+func (c CycList) EqualFunc(o interface{}, eq func(a, b interface{}) bool) bool {
+	return newComparison(true, eq).values(&c, o)
+}
+
+//	Determines whether o is a list or Sequence holding deeply equal elements, comparing nested Sequences by their contents
+func (l DoubleList) DeepEqual(o interface{}) bool {
+	return newComparison(true, deepValues).values(&l, o)
+}
+
+//	Determines whether o is a list or Sequence whose elements are equal under eq, comparing nested Sequences by their contents
+func (l DoubleList) EqualFunc(o interface{}, eq func(a, b interface{}) bool) bool {
+	return newComparison(true, eq).values(&l, o)
+}
+
+//	Determines whether o is a loop holding deeply equal elements, comparing nested Sequences by their contents
+func (c DoubleLoop) DeepEqual(o interface{}) bool {
+	return newComparison(true, deepValues).values(&c, o)
+}
+
+//	Determines whether o is a loop whose elements are equal under eq, comparing nested Sequences by their contents
+func (c DoubleLoop) EqualFunc(o interface{}, eq func(a, b interface{}) bool) bool {
+	return newComparison(true, eq).values(&c, o)
+}
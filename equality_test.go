@@ -0,0 +1,151 @@
+package lists
+
+import "github.com/feyeleanor/chain"
+import "strings"
+import "testing"
+
+//	A minimal node type which does not implement Equatable
+type plainNode struct {
+	value		interface{}
+	next		*plainNode
+}
+
+func (n *plainNode) Content() (r interface{}) {
+	if n != nil {
+		r = n.value
+	}
+	return
+}
+
+func (n *plainNode) MoveTo(i int) (r chain.Node) {
+	for ; n != nil && i > 0; i-- {
+		n = n.next
+	}
+	if n != nil && i == 0 {
+		r = n
+	}
+	return
+}
+
+func (n *plainNode) Link(i int, l chain.Node) bool {
+	if i == chain.NEXT_NODE {
+		x, _ := l.(*plainNode)
+		n.next = x
+		return true
+	}
+	return false
+}
+
+func (n *plainNode) Set(i int, v interface{}) bool {
+	if i == chain.CURRENT_NODE {
+		n.value = v
+		return true
+	}
+	return false
+}
+
+func plainList(items... interface{}) (l *LinearList) {
+	l = NewLinearList(&plainNode{})
+	l.Concatenate(items)
+	return
+}
+
+//	A Sequence which is not one of the list types
+type sliceSequence []interface{}
+
+func (s sliceSequence) Len() int { return len(s) }
+func (s sliceSequence) At(i int) interface{} { return s[i] }
+func (s sliceSequence) Set(i int, v interface{}) { s[i] = v }
+func (s sliceSequence) Each(f func(interface{})) {
+	for _, v := range s {
+		f(v)
+	}
+}
+
+func TestEqualByContent(t *testing.T) {
+	ConfirmEqual := func(l, o *LinearList) {
+		if !l.Equal(o) || !o.Equal(l) {
+			t.Fatalf("%v should equal %v", l, o)
+		}
+	}
+	RefuteEqual := func(l, o *LinearList) {
+		if l.Equal(o) || o.Equal(l) {
+			t.Fatalf("%v should not equal %v", l, o)
+		}
+	}
+	ConfirmEqual(plainList(), plainList())
+	ConfirmEqual(plainList(1, "a"), plainList(1, "a"))
+	ConfirmEqual(plainList(1, "a"), List(1, "a"))
+	ConfirmEqual(plainList(List(1)), List(plainList(1)))
+	ConfirmEqual(List([]int{ 1, 2 }), List([]int{ 1, 2 }))
+	RefuteEqual(plainList(1, "a"), plainList(1, "b"))
+	RefuteEqual(plainList(1, 2), List(1, 3))
+	RefuteEqual(List([]int{ 1, 2 }), List([]int{ 2, 1 }))
+	RefuteEqual(List(List(1)), List(Loop(1)))
+
+	l := NewLinearList(&DoubleNode{})
+	l.Concatenate([]interface{}{ 1, 2 })
+	ConfirmEqual(List(1, 2), l)
+
+	if Loop(1).Equal(1) {
+		t.Fatalf("a loop should not equal an element")
+	}
+	if Loop().Equal(List()) {
+		t.Fatalf("a loop should not equal a list")
+	}
+}
+
+func TestDeepEqual(t *testing.T) {
+	type point struct {
+		X, Y		int
+		Tags		[]string
+	}
+	ConfirmDeepEqual := func(l *LinearList, o interface{}) {
+		if !l.DeepEqual(o) {
+			t.Fatalf("%v should be deeply equal to %v", l, o)
+		}
+	}
+	RefuteDeepEqual := func(l *LinearList, o interface{}) {
+		if l.DeepEqual(o) {
+			t.Fatalf("%v should not be deeply equal to %v", l, o)
+		}
+	}
+	ConfirmDeepEqual(List(), List())
+	ConfirmDeepEqual(List(1, List(2, 3)), List(1, List(2, 3)))
+	ConfirmDeepEqual(List(1, List(2, 3)), List(1, DList(2, 3)))
+	ConfirmDeepEqual(List(1, List(2, 3)), DList(1, plainList(2, 3)))
+	ConfirmDeepEqual(List(1, List(2)), sliceSequence{ 1, sliceSequence{ 2 } })
+	ConfirmDeepEqual(List(&point{ 1, 2, []string{ "a" } }), List(&point{ 1, 2, []string{ "a" } }))
+	ConfirmDeepEqual(List(map[string]int{ "a": 1 }), List(map[string]int{ "a": 1 }))
+	RefuteDeepEqual(List(&point{ 1, 2, nil }), List(&point{ 1, 3, nil }))
+	RefuteDeepEqual(List(1, Loop(2)), List(1, List(2)))
+	RefuteDeepEqual(List(1), Loop(1))
+	RefuteDeepEqual(List(1, 2), sliceSequence{ 1 })
+	RefuteDeepEqual(List(1), 1)
+
+	if !Loop(1, DLoop(2)).DeepEqual(DLoop(1, Loop(2))) {
+		t.Fatalf("loops of different types should be deeply equal")
+	}
+
+	l := List(1)
+	l.Append(l)
+	o := plainList(1)
+	o.Append(o)
+	if !l.DeepEqual(o) {
+		t.Fatalf("%v should be deeply equal to %v", l, o)
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	caseless := func(a, b interface{}) bool {
+		x, xok := a.(string)
+		y, yok := b.(string)
+		return xok && yok && strings.EqualFold(x, y)
+	}
+	switch {
+	case !List("a", List("B")).EqualFunc(List("A", List("b")), caseless):	t.Fatalf("EqualFunc() should ignore case")
+	case List("a", List("B")).EqualFunc(List("A", List("c")), caseless):	t.Fatalf("EqualFunc() should compare nested elements")
+	case !Loop("x").EqualFunc(DLoop("X"), caseless):						t.Fatalf("EqualFunc() should compare loops by content")
+	case List("a").EqualFunc(List("a", "b"), caseless):					t.Fatalf("EqualFunc() should compare lengths")
+	}
+}
@@ -0,0 +1,108 @@
+package lists
+
+/*
+	Flattened, FlattenDepth and FlattenFunc build a new list from the elements of a list and
+	those of its nested lists, leaving the original list and its sublists intact.
+
+	Nested lists and other Iterable elements are inlined, whilst Flattenable elements which
+	are not Iterable cannot be inlined without being altered and so are kept as elements. As
+	with Flatten an empty nested list becomes nil. A sublist which is reached more than once is
+	inlined each time it appears, except where it appears inside itself, in which case the
+	sublist itself is kept as an element rather than being inlined forever.
+*/
+
+type flattener struct {
+	inline		func(interface{}) bool
+	path		map[listKey]bool
+}
+
+//	Determines whether an element is one which can be inlined without being altered
+func inlinable(v interface{}) (ok bool) {
+	if ok = headerOf(v) != nil; !ok {
+		_, ok = v.(Iterable)
+	}
+	return
+}
+
+//	Appends v to r, inlining it and its own elements to the given depth. A negative depth is unlimited.
+func (f *flattener) add(r *ListHeader, v interface{}, depth int) {
+	if depth != 0 && inlinable(v) && f.inline(v) {
+		if h := headerOf(v); h == nil {
+			v.(Iterable).Each(func(x interface{}) { f.add(r, x, depth - 1) })
+			return
+		} else if k := h.key(); h.length == 0 {
+			v = nil
+		} else if !f.path[k] {
+			f.path[k] = true
+			for x := range h.Values() {
+				f.add(r, x, depth - 1)
+			}
+			delete(f.path, k)
+			return
+		}
+	}
+	r.appendValue(v)
+}
+
+func (l ListHeader) flattened(depth int, inline func(interface{}) bool) (r *ListHeader) {
+	r = l.newHeader()
+	f := &flattener{ inline: inline, path: map[listKey]bool{ l.key(): l.length > 0 } }
+	for v := range l.Values() {
+		f.add(r, v, depth)
+	}
+	return
+}
+
+func inlineAll(interface{}) bool {
+	return true
+}
+
+//	Returns a new list of the same node type holding the elements of the list with all nesting removed
+func (l ListHeader) Flattened() *ListHeader {
+	return l.flattened(-1, inlineAll)
+}
+
+//	Returns a new list of the same node type holding the elements of the list with up to n levels of nesting removed
+func (l ListHeader) FlattenDepth(n int) *ListHeader {
+	if n < 0 {
+		n = 0
+	}
+	return l.flattened(n, inlineAll)
+}
+
+//	Returns a new list of the same node type in which those nested lists and Iterables for which f is true are inlined
+func (l ListHeader) FlattenFunc(f func(interface{}) bool) *ListHeader {
+	return l.flattened(-1, f)
+}
+
+
+func (l LinearList) Flattened() *LinearList {
+	return &LinearList{ *l.ListHeader.Flattened() }
+}
+
+func (l LinearList) FlattenDepth(n int) *LinearList {
+	return &LinearList{ *l.ListHeader.FlattenDepth(n) }
+}
+
+func (l LinearList) FlattenFunc(f func(interface{}) bool) *LinearList {
+	return &LinearList{ *l.ListHeader.FlattenFunc(f) }
+}
+
+
+func (c CycList) Flattened() (r *CycList) {
+	r = &CycList{ *c.ListHeader.Flattened() }
+	r.closeLoop()
+	return
+}
+
+func (c CycList) FlattenDepth(n int) (r *CycList) {
+	r = &CycList{ *c.ListHeader.FlattenDepth(n) }
+	r.closeLoop()
+	return
+}
+
+func (c CycList) FlattenFunc(f func(interface{}) bool) (r *CycList) {
+	r = &CycList{ *c.ListHeader.FlattenFunc(f) }
+	r.closeLoop()
+	return
+}
@@ -0,0 +1,81 @@
+package lists
+
+import "testing"
+
+func TestFlattened(t *testing.T) {
+	ConfirmFlattened := func(l, r *LinearList) {
+		before := l.String()
+		switch x := l.Flattened(); {
+		case !x.Equal(r):				t.Fatalf("%v.Flattened() should be %v but is %v", before, r, x)
+		case l.String() != before:		t.Fatalf("Flattened() should leave %v untouched but it is now %v", before, l)
+		}
+	}
+	ConfirmFlattened(List(), List())
+	ConfirmFlattened(List(1), List(1))
+	ConfirmFlattened(List(List(1)), List(1))
+	ConfirmFlattened(List(1, List(2, List(3, List(4))), 5), List(1, 2, 3, 4, 5))
+	ConfirmFlattened(List(1, List(), 2), List(1, nil, 2))
+	ConfirmFlattened(List(1, Loop(2, 3)), List(1, 2, 3))
+	ConfirmFlattened(List(1, DList(2, 3)), List(1, 2, 3))
+	ConfirmFlattened(List(1, sliceSequence{ 2, List(3) }), List(1, 2, 3))
+
+	s := List(1, List(2))
+	l := List(s, 0, s)
+	ConfirmFlattened(l, List(1, 2, 0, 1, 2))
+	if !s.Equal(List(1, List(2))) || l.At(0) != s || l.At(2) != s {
+		t.Fatalf("Flattened() should not alter the shared sublist %v", s)
+	}
+
+	l = List(1)
+	l.Append(l)
+	if x := l.Flattened(); x.Len() != 2 || x.At(0) != 1 || x.At(1) != l {
+		t.Fatalf("Flattened() should keep a self reference as an element not %v", x)
+	}
+
+	x := List(0)
+	y := List(1, x)
+	x.Append(y)
+	if r := List(x).Flattened(); r.Len() != 3 || r.At(0) != 0 || r.At(1) != 1 || r.At(2) != x {
+		t.Fatalf("Flattened() of mutually nested lists should be (0 1 %v) not %v", x, r)
+	}
+
+	c := Loop(1, List(2, 3))
+	switch r := c.Flattened(); {
+	case !r.Equal(Loop(1, 2, 3)):		t.Fatalf("Flattened() should be %v but is %v", Loop(1, 2, 3), r)
+	case r.Validate() != nil:			t.Fatalf("Flattened() should be a valid loop: %v", r.Validate())
+	}
+}
+
+func TestFlattenDepth(t *testing.T) {
+	ConfirmFlattenDepth := func(l *LinearList, n int, r *LinearList) {
+		if x := l.FlattenDepth(n); !x.Equal(r) {
+			t.Fatalf("%v.FlattenDepth(%v) should be %v but is %v", l, n, r, x)
+		}
+	}
+	l := List(1, List(2, List(3, List(4))))
+	ConfirmFlattenDepth(l, -1, l)
+	ConfirmFlattenDepth(l, 0, l)
+	ConfirmFlattenDepth(l, 1, List(1, 2, List(3, List(4))))
+	ConfirmFlattenDepth(l, 2, List(1, 2, 3, List(4)))
+	ConfirmFlattenDepth(l, 3, List(1, 2, 3, 4))
+	ConfirmFlattenDepth(l, 9, List(1, 2, 3, 4))
+	ConfirmFlattenDepth(List(List(), List(List())), 1, List(nil, List()))
+
+	if r := Loop(List(1, List(2))).FlattenDepth(1); !r.Equal(Loop(1, List(2))) {
+		t.Fatalf("FlattenDepth(1) should be %v but is %v", Loop(1, List(2)), r)
+	}
+}
+
+func TestFlattenFunc(t *testing.T) {
+	noLoops := func(v interface{}) bool {
+		_, ok := v.(*CycList)
+		return !ok
+	}
+	l := List(1, List(2, Loop(3, List(4))), Loop(5))
+	if r := l.FlattenFunc(noLoops); !r.Equal(List(1, 2, Loop(3, List(4)), Loop(5))) {
+		t.Fatalf("FlattenFunc() should keep loops intact not %v", r)
+	}
+	if r := l.FlattenFunc(func(interface{}) bool { return false }); !r.Equal(l) {
+		t.Fatalf("FlattenFunc() should inline nothing not %v", r)
+	}
+}
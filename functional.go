@@ -0,0 +1,187 @@
+package lists
+
+import "reflect"
+import "github.com/feyeleanor/chain"
+
+//	Links the end of a list back to its start
+func (l *ListHeader) closeLoop() {
+	if l.end != nil {
+		l.end.Link(chain.NEXT_NODE, l.start)
+	}
+}
+
+//	Returns a new list of the same node type holding the result of applying f to each element
+func (l ListHeader) Map(f func(interface{}) interface{}) (r *ListHeader) {
+	r = l.newHeader()
+	l.Each(func(v interface{}) { r.Append(f(v)) })
+	return
+}
+
+//	Returns a new list of the same node type holding the elements for which f is true
+func (l ListHeader) Filter(f func(interface{}) bool) (r *ListHeader) {
+	r = l.newHeader()
+	l.Each(func(v interface{}) {
+		if f(v) {
+			r.Append(v)
+		}
+	})
+	return
+}
+
+//	Returns a new list of the same node type holding the elements for which f is false
+func (l ListHeader) Reject(f func(interface{}) bool) *ListHeader {
+	return l.Filter(func(v interface{}) bool { return !f(v) })
+}
+
+//	Returns a new list of the same node type holding the concatenated results of applying f to each element.
+//	Results which are Sequences or slices contribute each of their elements, whilst any other result is added as a single element.
+func (l ListHeader) FlatMap(f func(interface{}) interface{}) (r *ListHeader) {
+	r = l.newHeader()
+	l.Each(func(v interface{}) {
+		switch x := f(v); x.(type) {
+		case Sequence:		r.Concatenate(x)
+		default:			if reflect.ValueOf(x).Kind() == reflect.Slice {
+								r.Concatenate(x)
+							} else {
+								r.Append(x)
+							}
+		}
+	})
+	return
+}
+
+//	Combines the elements of the list from the start, using the first element as the initial value.
+//	Returns nil for an empty list.
+func (l ListHeader) Reduce(f func(memo, v interface{}) interface{}) (r interface{}) {
+	for i, v := range l.All() {
+		if i == 0 {
+			r = v
+		} else {
+			r = f(r, v)
+		}
+	}
+	return
+}
+
+//	Combines the elements of the list with an initial value, starting from the first element
+func (l ListHeader) FoldLeft(seed interface{}, f func(memo, v interface{}) interface{}) interface{} {
+	for v := range l.Values() {
+		seed = f(seed, v)
+	}
+	return seed
+}
+
+//	Combines the elements of the list with an initial value, starting from the last element
+func (l ListHeader) FoldRight(seed interface{}, f func(v, memo interface{}) interface{}) interface{} {
+	for _, v := range l.Backward() {
+		seed = f(v, seed)
+	}
+	return seed
+}
+
+//	Determines whether f is true for any element of the list
+func (l ListHeader) Any(f func(interface{}) bool) bool {
+	return l.FindIndex(f) > -1
+}
+
+//	Determines whether f is true for every element of the list
+func (l ListHeader) Every(f func(interface{}) bool) bool {
+	return l.FindIndex(func(v interface{}) bool { return !f(v) }) == -1
+}
+
+//	Counts the elements of the list for which f is true
+func (l ListHeader) Count(f func(interface{}) bool) (c int) {
+	for v := range l.Values() {
+		if f(v) {
+			c++
+		}
+	}
+	return
+}
+
+//	Returns the first element of the list for which f is true
+func (l ListHeader) Find(f func(interface{}) bool) (r interface{}, ok bool) {
+	for v := range l.Values() {
+		if f(v) {
+			return v, true
+		}
+	}
+	return
+}
+
+//	Returns the position of the first element of the list for which f is true, or -1 if there is none
+func (l ListHeader) FindIndex(f func(interface{}) bool) int {
+	for i, v := range l.All() {
+		if f(v) {
+			return i
+		}
+	}
+	return -1
+}
+
+//	Replaces each element of the list with the result of applying f to it
+func (l *ListHeader) MapInPlace(f func(interface{}) interface{}) {
+	l.eachNode(func(i int, n chain.Node) {
+		n.Set(chain.CURRENT_NODE, f(n.Content()))
+	})
+}
+
+//	Unlinks the elements of the list for which f is false, without allocating new nodes
+func (l *ListHeader) FilterInPlace(f func(interface{}) bool) {
+	if c := l.Cursor(0); c != nil {
+		for i := l.length; i > 0; i-- {
+			if f(c.Value()) {
+				c.Next()
+			} else {
+				c.Remove()
+			}
+		}
+	}
+}
+
+//	Unlinks the elements of the list for which f is true, without allocating new nodes
+func (l *ListHeader) RejectInPlace(f func(interface{}) bool) {
+	l.FilterInPlace(func(v interface{}) bool { return !f(v) })
+}
+
+
+func (l LinearList) Map(f func(interface{}) interface{}) *LinearList {
+	return &LinearList{ *l.ListHeader.Map(f) }
+}
+
+func (l LinearList) Filter(f func(interface{}) bool) *LinearList {
+	return &LinearList{ *l.ListHeader.Filter(f) }
+}
+
+func (l LinearList) Reject(f func(interface{}) bool) *LinearList {
+	return &LinearList{ *l.ListHeader.Reject(f) }
+}
+
+func (l LinearList) FlatMap(f func(interface{}) interface{}) *LinearList {
+	return &LinearList{ *l.ListHeader.FlatMap(f) }
+}
+
+
+func (c CycList) Map(f func(interface{}) interface{}) (r *CycList) {
+	r = &CycList{ *c.ListHeader.Map(f) }
+	r.closeLoop()
+	return
+}
+
+func (c CycList) Filter(f func(interface{}) bool) (r *CycList) {
+	r = &CycList{ *c.ListHeader.Filter(f) }
+	r.closeLoop()
+	return
+}
+
+func (c CycList) Reject(f func(interface{}) bool) (r *CycList) {
+	r = &CycList{ *c.ListHeader.Reject(f) }
+	r.closeLoop()
+	return
+}
+
+func (c CycList) FlatMap(f func(interface{}) interface{}) (r *CycList) {
+	r = &CycList{ *c.ListHeader.FlatMap(f) }
+	r.closeLoop()
+	return
+}
@@ -0,0 +1,154 @@
+package lists
+
+import "github.com/feyeleanor/chain"
+import "reflect"
+import "testing"
+
+func twice(v interface{}) interface{} {
+	return v.(int) * 2
+}
+
+func isEven(v interface{}) bool {
+	return v.(int) % 2 == 0
+}
+
+func TestLinearListMap(t *testing.T) {
+	ConfirmMap := func(l, r *LinearList) {
+		if x := l.Map(twice); !x.Equal(r) {
+			t.Fatalf("%v.Map() should be %v but is %v", l, r, x)
+		}
+	}
+	ConfirmMap(List(), List())
+	ConfirmMap(List(1), List(2))
+	ConfirmMap(List(1, 2, 3), List(2, 4, 6))
+
+	l := NewLinearList(&DoubleNode{})
+	l.Concatenate([]interface{}{ 1, 2 })
+	if x := l.Map(twice); reflect.TypeOf(x.Start()) != reflect.TypeOf(&DoubleNode{}) {
+		t.Fatalf("Map() should use the node type of %v", l)
+	}
+}
+
+func TestCycListMap(t *testing.T) {
+	c := Loop(1, 2, 3)
+	x := c.Map(twice)
+	switch {
+	case !x.Equal(Loop(2, 4, 6)):			t.Fatalf("%v.Map() should be %v but is %v", c, Loop(2, 4, 6), x)
+	case chain.Next(x.end) != x.start:		t.Fatalf("%v.Map() should be closed", c)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	ConfirmFilter := func(l, r *LinearList) {
+		if x := l.Filter(isEven); !x.Equal(r) {
+			t.Fatalf("%v.Filter() should be %v but is %v", l, r, x)
+		}
+	}
+	ConfirmFilter(List(), List())
+	ConfirmFilter(List(1), List())
+	ConfirmFilter(List(1, 2, 3, 4), List(2, 4))
+
+	if x := List(1, 2, 3, 4).Reject(isEven); !x.Equal(List(1, 3)) {
+		t.Fatalf("Reject() should be %v but is %v", List(1, 3), x)
+	}
+
+	c := Loop(1, 2, 3, 4).Filter(isEven)
+	if !c.Equal(Loop(2, 4)) || c.At(2) != 2 {
+		t.Fatalf("Filter() should be %v but is %v", Loop(2, 4), c)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	l := List(1, 2, 3).FlatMap(func(v interface{}) interface{} {
+		switch v {
+		case 1:		return []interface{}{ 1, 1 }
+		case 2:		return List(2, 2)
+		}
+		return v
+	})
+	if r := List(1, 1, 2, 2, 3); !l.Equal(r) {
+		t.Fatalf("FlatMap() should be %v but is %v", r, l)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	sum := func(memo, v interface{}) interface{} { return memo.(int) + v.(int) }
+	switch {
+	case List().Reduce(sum) != nil:				t.Fatalf("Reduce() of an empty list should be nil")
+	case List(1).Reduce(sum) != 1:				t.Fatalf("Reduce() should be 1")
+	case List(1, 2, 3, 4).Reduce(sum) != 10:	t.Fatalf("Reduce() should be 10")
+	case Loop(1, 2, 3, 4).Reduce(sum) != 10:	t.Fatalf("Reduce() should be 10")
+	}
+}
+
+func TestFold(t *testing.T) {
+	l := List("a", "b", "c")
+	left := l.FoldLeft("", func(memo, v interface{}) interface{} { return memo.(string) + v.(string) })
+	right := l.FoldRight("", func(v, memo interface{}) interface{} { return memo.(string) + v.(string) })
+	switch {
+	case left != "abc":		t.Fatalf("FoldLeft() should be abc not %v", left)
+	case right != "cba":	t.Fatalf("FoldRight() should be cba not %v", right)
+	}
+}
+
+func TestPredicates(t *testing.T) {
+	l := List(1, 2, 3, 4)
+	switch {
+	case !l.Any(isEven):					t.Fatalf("%v.Any() should be true", l)
+	case List(1, 3).Any(isEven):			t.Fatalf("Any() should be false")
+	case l.Every(isEven):					t.Fatalf("%v.Every() should be false", l)
+	case !List(2, 4).Every(isEven):			t.Fatalf("Every() should be true")
+	case !List().Every(isEven):				t.Fatalf("Every() of an empty list should be true")
+	case l.Count(isEven) != 2:				t.Fatalf("%v.Count() should be 2 not %v", l, l.Count(isEven))
+	case l.FindIndex(isEven) != 1:			t.Fatalf("%v.FindIndex() should be 1", l)
+	case List(1, 3).FindIndex(isEven) != -1:	t.Fatalf("FindIndex() should be -1")
+	}
+	if v, ok := l.Find(isEven); !ok || v != 2 {
+		t.Fatalf("%v.Find() should be 2 not %v", l, v)
+	}
+	if _, ok := List(1, 3).Find(isEven); ok {
+		t.Fatalf("Find() should fail")
+	}
+}
+
+func TestMapInPlace(t *testing.T) {
+	l := List(1, 2, 3)
+	start := l.Start()
+	l.MapInPlace(twice)
+	switch {
+	case !l.Equal(List(2, 4, 6)):	t.Fatalf("MapInPlace() should be %v but is %v", List(2, 4, 6), l)
+	case l.Start() != start:		t.Fatalf("MapInPlace() should reuse the nodes of %v", l)
+	}
+}
+
+func TestFilterInPlace(t *testing.T) {
+	ConfirmFilterInPlace := func(l, r *LinearList) {
+		l.FilterInPlace(isEven)
+		switch {
+		case !l.Equal(r):			t.Fatalf("FilterInPlace() should be %v but is %v", r, l)
+		case l.End() != l.findNode(l.Len() - 1):	t.Fatalf("FilterInPlace() end of %v is incorrect", l)
+		}
+	}
+	ConfirmFilterInPlace(List(), List())
+	ConfirmFilterInPlace(List(1), List())
+	ConfirmFilterInPlace(List(2), List(2))
+	ConfirmFilterInPlace(List(1, 2, 3, 4, 5), List(2, 4))
+	ConfirmFilterInPlace(List(2, 4, 5, 7), List(2, 4))
+
+	ConfirmLoopFilterInPlace := func(c, r *CycList) {
+		c.FilterInPlace(isEven)
+		switch {
+		case !c.Equal(r):									t.Fatalf("FilterInPlace() should be %v but is %v", r, c)
+		case c.Len() > 0 && chain.Next(c.end) != c.start:	t.Fatalf("FilterInPlace() should keep %v closed", c)
+		}
+	}
+	ConfirmLoopFilterInPlace(Loop(1, 3), Loop())
+	ConfirmLoopFilterInPlace(Loop(1, 2, 3, 4, 5), Loop(2, 4))
+	ConfirmLoopFilterInPlace(Loop(2, 3, 4), Loop(2, 4))
+
+	l := List(1, 2, 3, 4)
+	l.RejectInPlace(isEven)
+	if !l.Equal(List(1, 3)) {
+		t.Fatalf("RejectInPlace() should be %v but is %v", List(1, 3), l)
+	}
+}
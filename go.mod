@@ -1,8 +1,5 @@
 module github.com/feyeleanor/lists
 
-go 1.17
+go 1.23
 
-require (
-	github.com/feyeleanor/chain v0.0.0-20161231001018-ef8fa8a672db // indirect
-	github.com/feyeleanor/jittery v0.0.0-20161230190557-3fb4c65493ea // indirect
-)
+require github.com/feyeleanor/chain v0.0.0-20161231001018-ef8fa8a672db
@@ -2,7 +2,9 @@ package lists
 
 import "github.com/feyeleanor/chain"
 import "fmt"
+import "iter"
 import "reflect"
+import "strconv"
 import "strings"
 
 
@@ -10,8 +12,14 @@ type ListHeader struct {
 	nodeType	reflect.Type
 	start 		chain.Node
 	end			chain.Node
-	cache		cachedNode
+	reversed	bool
+	cache		PositionCache
 	length		int
+	debug		debugMode
+	numbers		NumberMode
+	modifications	int
+	predecessors	[]chain.Node
+	predecessorBase	int
 }
 
 func NewListHeader(n chain.Node) ListHeader {
@@ -19,7 +27,7 @@ func NewListHeader(n chain.Node) ListHeader {
 	if t.Kind() != reflect.Ptr {
 		t = reflect.PtrTo(t)
 	}
-	return ListHeader{ nodeType: t }
+	return ListHeader{ nodeType: t, cache: NewFingerCache() }
 }
 
 func (l ListHeader) newListNode() chain.Node {
@@ -32,6 +40,8 @@ func (l ListHeader) NewListNode(value interface{}) (n chain.Node) {
 	return
 }
 
+//	Clamps the inclusive range start to end so that it lies within the list, reporting whether any of the range remains.
+//	CheckBounds reports rather than corrects a range which overhangs the list.
 func (l ListHeader) EnforceBounds(start, end *int) (ok bool) {
 	if *start < 0 {
 		*start = 0
@@ -51,21 +61,38 @@ func (l *ListHeader) Erase() {
 	l.start = nil
 	l.end = nil
 	l.length = 0
-	l.cache.Clear()
+	l.reversed = false
+	l.invalidate(0)
 }
 
+//	Lists which are shared or which contain themselves are labelled on first appearance as #n=(...) and written as #n# thereafter.
 func (l ListHeader) String() (t string) {
-	terms := []string{}
-	l.Each(func(term interface{}) {
-		terms = append(terms, fmt.Sprintf("%v", term))
-	})
-	if l.length > 0 && l.start == chain.Next(l.end) {
-		terms = append(terms, "...")
+	p := newPrinter()
+	p.scan(&l)
+	return p.format(&l)
+}
+
+//	Formats a list element so that it can be read back by Parse().
+//	Strings are quoted, floating point numbers always include a decimal point or exponent, and nil is written as nil.
+func formatTerm(term interface{}) (t string) {
+	switch term := term.(type) {
+	case string:			t = strconv.Quote(term)
+	case float32:			t = formatFloat(float64(term), 32)
+	case float64:			t = formatFloat(term, 64)
+	default:				t = fmt.Sprintf("%v", term)
+	}
+	if t == "<nil>" {
+		t = "nil"
+	}
+	return
+}
+
+func formatFloat(f float64, bits int) (t string) {
+	t = strconv.FormatFloat(f, 'g', -1, bits)
+	if !strings.ContainsAny(t, ".eEnN") {
+		t += ".0"
 	}
-	t = strings.Join(terms, " ")
-	t = strings.Replace(t, "()", "nil", -1)
-	t = strings.Replace(t, "<nil>", "nil", -1)
-	return "(" + t + ")"
+	return
 }
 
 func (l ListHeader) Len() (c int) {
@@ -80,21 +107,27 @@ func (l ListHeader) End() chain.Node {
 	return l.end
 }
 
+//	Creates an empty list header using the same node type
+func (l ListHeader) newHeader() *ListHeader {
+	return &ListHeader{ nodeType: l.nodeType, cache: NewFingerCache() }
+}
+
 func (l ListHeader) Clone() (r *ListHeader) {
-	r = &ListHeader{ nodeType: l.nodeType }
+	r = l.newHeader()
 	l.Each(func(v interface{}) { r.Append(v) })
 	return
 }
 
 func (l *ListHeader) Expand(i, n int) {
 	if i > -1 && i <= l.length {
+		l.straighten()
 		switch {
 		case l == nil:					fallthrough
 		case i == l.length:				for ; n > 0; n-- {
-											l.Append(l.newListNode())
+											l.appendValue(nil)
 										}
 
-		case i == 0:					l.length = n
+		case i == 0:					l.length += n
 										for ; n > 0; n-- {
 											x := l.newListNode()
 											x.Link(chain.NEXT_NODE, l.start)
@@ -110,6 +143,7 @@ func (l *ListHeader) Expand(i, n int) {
 										}
 										x1.Link(chain.NEXT_NODE, x2)
 		}
+		l.invalidate(i)
 	}
 }
 
@@ -117,25 +151,55 @@ func (l ListHeader) Each(f func(interface{})) {
 	n := l.start
 	for i := l.length; i > 0; i-- {
 		f(n.Content())
-		n = chain.Next(n)
+		n = l.next(n)
 	}
 }
 
-func (l ListHeader) equal(o ListHeader) (r bool) {
-	if l.length == o.length {
-		var e	Equatable
+//	Returns an iterator over the index and value of each element, in order from the start of the list.
+//	Unlike Each, iteration can be terminated early by breaking out of the range loop.
+func (l ListHeader) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		n := l.start
+		for i := 0; i < l.length; i++ {
+			if !yield(i, n.Content()) {
+				return
+			}
+			n = l.next(n)
+		}
+	}
+}
 
-		r = true
+//	Returns an iterator over the value of each element, in order from the start of the list.
+func (l ListHeader) Values() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
 		n := l.start
-		x := o.start
-		for i := l.length; r && i > 0; i-- {
-			if e, r = n.(Equatable); r && e.Equal(x) {
-				n = chain.Next(n)
-				x = chain.Next(x)
+		for i := l.length; i > 0; i-- {
+			if !yield(n.Content()) {
+				return
 			}
+			n = l.next(n)
 		}
 	}
-	return
+}
+
+//	Returns an iterator over the index and value of each element, in order from the end of the list.
+//	As nodes are singly linked the list is walked once to collect them before iteration begins.
+func (l ListHeader) Backward() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		nodes := make([]chain.Node, 0, l.length)
+		l.eachNode(func(i int, n chain.Node) {
+			nodes = append(nodes, n)
+		})
+		for i := len(nodes) - 1; i > -1; i-- {
+			if !yield(i, nodes[i].Content()) {
+				return
+			}
+		}
+	}
+}
+
+func (l ListHeader) equal(o ListHeader) (r bool) {
+	return newComparison(false, equalValues).lists(&l, &o)
 }
 
 func (l ListHeader) Equal(o interface{}) (r bool) {
@@ -150,7 +214,7 @@ func (l *ListHeader) eachNode(f func(int, chain.Node)) {
 	n := l.start
 	for i := 0; i < l.length; i++ {
 		f(i, n)
-		n = chain.Next(n)
+		n = l.next(n)
 	}
 }
 
@@ -158,13 +222,18 @@ func (l ListHeader) findNode(i int) (n chain.Node) {
 	switch {
 	case i == 0:				n = l.start
 	case i == l.length - 1:		n = l.end
-	default:					start, offset := l.cache.ClosestNode(i)
-								if start == nil {
-									start = l.start
+	case i < 0 || i >= l.length:
+	case l.cache == nil:		n = l.move(l.start, i)
+	default:					var offset int
+								if n, offset = l.cache.Closest(i); n == nil {
+									n, offset = l.start, 0
 								}
-								if n = start.MoveTo(i - offset); n != nil {
-									l.cache.Update(i, n)
+								for offset < i {
+									n = l.next(n)
+									offset++
+									l.cache.Passed(offset, n)
 								}
+								l.cache.Found(i, n)
 	}
 	return
 }
@@ -187,6 +256,13 @@ func (l ListHeader) Clear(i int) {
 }
 
 func (l *ListHeader) Append(v interface{}) {
+	l.appendValue(v)
+	l.check()
+}
+
+func (l *ListHeader) appendValue(v interface{}) {
+	l.straighten()
+	l.modifications++
 	switch {
 	case l.start == nil:	l.start = l.NewListNode(v)
 							l.end = l.start
@@ -200,9 +276,14 @@ func (l *ListHeader) Append(v interface{}) {
 }
 
 func (l *ListHeader) Concatenate(s interface{}) {
+	l.concatenate(s)
+	l.check()
+}
+
+func (l *ListHeader) concatenate(s interface{}) {
 	switch s := s.(type) {
 	case []interface{}:		if length := len(s); length > 0 {
-								l.Append(s[0])
+								l.appendValue(s[0])
 								if length > 1 {
 									tail := chain.Next(l.end)
 									for _, v := range s[1:] {
@@ -215,7 +296,7 @@ func (l *ListHeader) Concatenate(s interface{}) {
 							}
 
 	case Sequence:			if length := s.Len(); length > 0 {
-								l.Append(s.At(0))
+								l.appendValue(s.At(0))
 								if length > 1 {
 									tail := chain.Next(l.end)
 									for i := 1; i < length; i++ {
@@ -229,7 +310,7 @@ func (l *ListHeader) Concatenate(s interface{}) {
 
 	default:				switch s := reflect.ValueOf(s); s.Kind() {
 							case reflect.Slice:				if length := s.Len(); length > 0 {
-																l.Append(s.Index(0).Interface())
+																l.appendValue(s.Index(0).Interface())
 																if length > 1 {
 																	tail := chain.Next(l.end)
 																	for i := 1; i < length; i++ {
@@ -246,7 +327,9 @@ func (l *ListHeader) Concatenate(s interface{}) {
 
 //	Iterates through the list reducing the nesting of each element which can be flattened.
 //	Elements which are themselves LinearLists will be inlined as part of the containing list and their contained list destroyed.
+//	Flattened() builds a flattened copy instead, leaving the list and its sublists intact.
 func (l *ListHeader) Flatten() {
+	l.straighten()
 	l.eachNode(func(i int, n chain.Node) {
 		value := n.Content()
 		if h, ok := value.(Flattenable); ok {
@@ -259,21 +342,50 @@ func (l *ListHeader) Flatten() {
 
 			case length == 1:		n.Set(chain.CURRENT_NODE, h.Start().Content())
 
-			default:				l.length += length - 1
-									h.End().Link(chain.NEXT_NODE, chain.Next(n))
-									n.Link(chain.CURRENT_NODE, h.Start())
+			default:				start, end := h.Start(), h.End()
+									if reflect.TypeOf(start) != l.nodeType || isReversed(h) {
+										start, end = l.copyNodes(h)
+									}
+									l.length += length - 1
+									end.Link(chain.NEXT_NODE, chain.Next(n))
+									n.Link(chain.CURRENT_NODE, start)
 									if n == l.start {
-										l.start = h.Start()
+										l.start = start
 									}
 
 									if n == l.end {
-										l.end = h.End()
+										l.end = end
 									}
 			}
 		} else {
 			n.Set(chain.CURRENT_NODE, value)
 		}
 	})
+	l.invalidate(0)
+}
+
+//	Builds a chain of nodes of the list's own node type holding the contents of another list.
+//	This allows lists with a different node type, or whose nodes link in the opposite direction, to be spliced into the list.
+func (l ListHeader) copyNodes(h Linkable) (start, end chain.Node) {
+	appendNode := func(v interface{}) {
+		x := l.NewListNode(v)
+		if start == nil {
+			start = x
+		} else {
+			end.Link(chain.NEXT_NODE, x)
+		}
+		end = x
+	}
+	if s, ok := h.(Iterable); ok {
+		s.Each(appendNode)
+	} else {
+		n := h.Start()
+		for i := h.Len(); i > 0; i-- {
+			appendNode(n.Content())
+			n = chain.Next(n)
+		}
+	}
+	return
 }
 
 func (l ListHeader) Compact() []interface{} {
@@ -290,6 +402,7 @@ func (l ListHeader) Compact() []interface{} {
 func (l *ListHeader) Reverse() {
 	if l != nil {
 		current := l.start
+		closed := current != nil && chain.Next(l.end) == current
 		l.end = current
 
 		for i := l.length; i > 0; i-- {
@@ -298,21 +411,43 @@ func (l *ListHeader) Reverse() {
 			l.start = current
 			current = next				
 		}
+		if l.end != nil {
+			if closed {
+				l.end.Link(chain.NEXT_NODE, l.start)
+			} else {
+				l.end.Link(chain.NEXT_NODE, nil)
+			}
+		}
+		l.invalidate(0)
 	}
 	return
 }
 
 func (l ListHeader) Head() (r interface{}) {
 	if l.start != nil {
-		l.start.Content()
+		r = l.start.Content()
 	}
 	return
 }
 
 func (l *ListHeader) Tail() {
+	l.straighten()
 	if n := l.start; n != nil {
-		l.start = chain.Next(n)
+		if l.length == 1 {
+			l.start = nil
+			l.end = nil
+		} else {
+			l.start = chain.Next(n)
+			if chain.Next(l.end) == n {
+				l.end.Link(chain.NEXT_NODE, l.start)
+			} else {
+				l.start.Link(chain.PREVIOUS_NODE, nil)
+			}
+		}
 		n.Link(chain.NEXT_NODE, nil)
+		n.Link(chain.PREVIOUS_NODE, nil)
 		l.length--
+		l.shiftPredecessors(-1)
+		l.modified(0)
 	}
 }
\ No newline at end of file
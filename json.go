@@ -0,0 +1,262 @@
+package lists
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"github.com/feyeleanor/chain"
+)
+
+/*
+	Lists marshal to JSON as arrays, with any nested lists becoming nested arrays. A CycList
+	marshals as an object of the form {"cycle": [...]} so that it is decoded as a CycList
+	rather than as a LinearList.
+
+	Floating point numbers are always written with a decimal point or exponent, so that when
+	numbers are decoded as written an int and a float64 each come back as the type they were.
+	A list which contains itself cannot be represented and fails to marshal.
+
+	UnmarshalJSON is called by encoding/json without any options, so the NumberMode it uses
+	is a setting of the list being decoded into, chosen with UseNumberMode. The lists nested
+	within it are given the same NumberMode, so decoding into one of them later treats numbers
+	in the same way.
+*/
+
+//	Determines the Go type given to numbers decoded from JSON
+type NumberMode int
+
+const (
+	NUMBERS_AS_WRITTEN NumberMode = iota		//	int for integer literals and float64 for any other number
+	NUMBERS_AS_INT								//	int, with numbers having a fractional part reported as errors
+	NUMBERS_AS_FLOAT64							//	float64, as encoding/json does by default
+)
+
+type jsonEncoder struct {
+	path		map[listKey]bool
+}
+
+//	Converts a value into one which encoding/json marshals as intended, descending into any lists it contains
+func (j jsonEncoder) value(v interface{}) (r interface{}, e error) {
+	if h := headerOf(v); h != nil {
+		switch v.(type) {
+		case *CycList, *DoubleLoop:		r, e = j.cycle(h)
+		default:						r, e = j.list(h)
+		}
+	} else {
+		switch x := v.(type) {
+		case float32:					r = json.Number(formatFloat(float64(x), 32))
+		case float64:					r = json.Number(formatFloat(x, 64))
+		default:						r = v
+		}
+	}
+	return
+}
+
+func (j jsonEncoder) list(l *ListHeader) (r []interface{}, e error) {
+	k := l.key()
+	if j.path[k] {
+		return nil, &json.UnsupportedValueError{ Value: reflect.ValueOf(l), Str: "encountered a list which contains itself" }
+	}
+	if l.length > 0 {
+		j.path[k] = true
+		defer delete(j.path, k)
+	}
+	r = make([]interface{}, 0, l.length)
+	for v := range l.Values() {
+		var x interface{}
+		if x, e = j.value(v); e != nil {
+			return nil, e
+		}
+		r = append(r, x)
+	}
+	return
+}
+
+func (j jsonEncoder) cycle(l *ListHeader) (r interface{}, e error) {
+	var items []interface{}
+	if items, e = j.list(l); e == nil {
+		r = map[string]interface{}{ "cycle": items }
+	}
+	return
+}
+
+func marshalJSON(v interface{}) ([]byte, error) {
+	if x, e := (jsonEncoder{ make(map[listKey]bool) }).value(v); e != nil {
+		return nil, e
+	} else {
+		return json.Marshal(x)
+	}
+}
+
+//	The lists marshal with value receivers so that a list which is not addressable, such as one stored
+//	by value in a struct or a map, marshals as an array rather than as an empty object.
+func (l ListHeader) MarshalJSON() ([]byte, error) {
+	return marshalJSON(&l)
+}
+
+func (l LinearList) MarshalJSON() ([]byte, error) {
+	return marshalJSON(&l)
+}
+
+func (c CycList) MarshalJSON() ([]byte, error) {
+	return marshalJSON(&c)
+}
+
+func (l DoubleList) MarshalJSON() ([]byte, error) {
+	return marshalJSON(&l)
+}
+
+func (c DoubleLoop) MarshalJSON() ([]byte, error) {
+	return marshalJSON(&c)
+}
+
+
+type jsonDecoder struct {
+	nodeType	reflect.Type
+	numbers		NumberMode
+}
+
+//	Decodes a JSON document into lists, with arrays becoming LinearLists and {"cycle": [...]} objects becoming CycLists.
+//	Other objects become map[string]interface{} and numbers are decoded according to mode.
+func DecodeJSON(data []byte, mode NumberMode) (interface{}, error) {
+	return jsonDecoder{ reflect.TypeOf(&chain.Cell{}), mode }.decode(data)
+}
+
+func (j jsonDecoder) decode(data []byte) (r interface{}, e error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if e = d.Decode(&r); e == nil {
+		r, e = j.value(r)
+	}
+	return
+}
+
+func (j jsonDecoder) value(v interface{}) (r interface{}, e error) {
+	switch v := v.(type) {
+	case json.Number:				r, e = j.number(v)
+	case []interface{}:				l := &LinearList{ j.header() }
+									e = j.fill(&l.ListHeader, v)
+									r = l
+	case map[string]interface{}:	if items, ok := cycleItems(v); ok {
+										c := &CycList{ j.header() }
+										if e = j.fill(&c.ListHeader, items); e == nil {
+											c.closeLoop()
+										}
+										r = c
+									} else {
+										for k, x := range v {
+											if v[k], e = j.value(x); e != nil {
+												return
+											}
+										}
+										r = v
+									}
+	default:						r = v
+	}
+	return
+}
+
+//	Creates an empty header for a decoded list, which decodes any JSON later unmarshaled into it in the same NumberMode
+func (j jsonDecoder) header() ListHeader {
+	return ListHeader{ nodeType: j.nodeType, cache: NewFingerCache(), numbers: j.numbers }
+}
+
+//	Recognises the {"cycle": [...]} object used to represent a CycList
+func cycleItems(m map[string]interface{}) (items []interface{}, ok bool) {
+	if len(m) == 1 {
+		items, ok = m["cycle"].([]interface{})
+	}
+	return
+}
+
+func (j jsonDecoder) fill(l *ListHeader, items []interface{}) (e error) {
+	for i, v := range items {
+		if items[i], e = j.value(v); e != nil {
+			return
+		}
+	}
+	l.concatenate(items)
+	return
+}
+
+func (j jsonDecoder) number(n json.Number) (r interface{}, e error) {
+	switch j.numbers {
+	case NUMBERS_AS_FLOAT64:		r, e = n.Float64()
+	case NUMBERS_AS_INT:			if r, e = strconv.Atoi(string(n)); e != nil {
+										e = &json.UnmarshalTypeError{ Value: "number " + string(n), Type: reflect.TypeOf(0) }
+									}
+	default:						if i, x := strconv.Atoi(string(n)); x == nil {
+										r = i
+									} else {
+										r, e = n.Float64()
+									}
+	}
+	return
+}
+
+//	Decodes into the list from a JSON array, replacing its contents.
+//	Numbers are decoded according to the list's NumberMode, and nested lists use the same node type as the list.
+func (l *LinearList) UnmarshalJSON(data []byte) (e error) {
+	var v interface{}
+	if v, e = l.jsonDecoder().decode(data); e == nil {
+		if x, ok := v.(*LinearList); ok {
+			l.replace(&x.ListHeader)
+		} else {
+			e = &json.UnmarshalTypeError{ Value: jsonKind(v), Type: reflect.TypeOf(l) }
+		}
+	}
+	return
+}
+
+//	Decodes into the loop from a JSON object of the form {"cycle": [...]}, replacing its contents.
+//	Numbers are decoded according to the loop's NumberMode, and nested lists use the same node type as the loop.
+func (c *CycList) UnmarshalJSON(data []byte) (e error) {
+	var v interface{}
+	if v, e = c.jsonDecoder().decode(data); e == nil {
+		if x, ok := v.(*CycList); ok {
+			c.replace(&x.ListHeader)
+		} else {
+			e = &json.UnmarshalTypeError{ Value: jsonKind(v), Type: reflect.TypeOf(c) }
+		}
+	}
+	return
+}
+
+//	Selects the Go type given to numbers when JSON is decoded into the list by UnmarshalJSON.
+//	A list decodes numbers as written until another mode is selected.
+func (l *ListHeader) UseNumberMode(m NumberMode) {
+	l.numbers = m
+}
+
+//	A zero-valued list has no node type, in which case chain.Cell is used
+func (l *ListHeader) jsonDecoder() (j jsonDecoder) {
+	if j.nodeType = l.nodeType; j.nodeType == nil {
+		j.nodeType = reflect.TypeOf(&chain.Cell{})
+	}
+	j.numbers = l.numbers
+	return
+}
+
+//	Takes the nodes of another list, retaining the list's own cache, debug mode and number mode
+func (l *ListHeader) replace(o *ListHeader) {
+	l.nodeType = o.nodeType
+	l.start, l.end, l.length, l.reversed = o.start, o.end, o.length, o.reversed
+	if l.cache == nil {
+		l.cache = NewFingerCache()
+	}
+	l.invalidate(0)
+}
+
+func jsonKind(v interface{}) (r string) {
+	switch v.(type) {
+	case *LinearList:				r = "array"
+	case *CycList:					r = "cycle"
+	case map[string]interface{}:	r = "object"
+	case string:					r = "string"
+	case bool:						r = "bool"
+	case nil:						r = "null"
+	default:						r = "number"
+	}
+	return
+}
@@ -0,0 +1,177 @@
+package lists
+
+import "encoding/json"
+import "errors"
+import "reflect"
+import "testing"
+
+func TestMarshalJSON(t *testing.T) {
+	ConfirmMarshal := func(v interface{}, x string) {
+		if b, e := json.Marshal(v); e != nil {
+			t.Fatalf("Marshal(%v) failed: %v", v, e)
+		} else if string(b) != x {
+			t.Fatalf("Marshal(%v) should be %v not %v", v, x, string(b))
+		}
+	}
+	ConfirmMarshal(List(), `[]`)
+	ConfirmMarshal(List(1, "two", 3.0, 4.5, nil, true), `[1,"two",3.0,4.5,null,true]`)
+	ConfirmMarshal(List(1, List(2, List(3)), List()), `[1,[2,[3]],[]]`)
+	ConfirmMarshal(Loop(), `{"cycle":[]}`)
+	ConfirmMarshal(Loop(1, 2), `{"cycle":[1,2]}`)
+	ConfirmMarshal(List(0, Loop(1, List(2))), `[0,{"cycle":[1,[2]]}]`)
+	ConfirmMarshal(DList(1, 2), `[1,2]`)
+	ConfirmMarshal(DLoop(1, 2), `{"cycle":[1,2]}`)
+	ConfirmMarshal(map[string]interface{}{ "a": List(1) }, `{"a":[1]}`)
+	ConfirmMarshal(*List(1, 2), `[1,2]`)
+	ConfirmMarshal(*Loop(1, 2), `{"cycle":[1,2]}`)
+	ConfirmMarshal(*DList(1, 2), `[1,2]`)
+	ConfirmMarshal(*DLoop(1, 2), `{"cycle":[1,2]}`)
+	ConfirmMarshal(map[string]LinearList{ "a": *List(1) }, `{"a":[1]}`)
+	ConfirmMarshal(struct{ L LinearList; C CycList }{ *List(1), *Loop(2) }, `{"L":[1],"C":{"cycle":[2]}}`)
+
+	s := List(1)
+	ConfirmMarshal(List(s, s), `[[1],[1]]`)
+
+	l := List(0)
+	l.Append(l)
+	if _, e := json.Marshal(l); e == nil {
+		t.Fatalf("Marshal() of a list which contains itself should fail")
+	} else if x := new(json.UnsupportedValueError); !errors.As(e, &x) {
+		t.Fatalf("Marshal() should fail with an UnsupportedValueError not %v", e)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	ConfirmUnmarshal := func(s string, r *LinearList) {
+		l := List(9)
+		if e := json.Unmarshal([]byte(s), l); e != nil {
+			t.Fatalf("Unmarshal(%v) failed: %v", s, e)
+		} else if !l.Equal(r) {
+			t.Fatalf("Unmarshal(%v) should be %v but is %v", s, r, l)
+		}
+	}
+	ConfirmUnmarshal(`[]`, List())
+	ConfirmUnmarshal(`[1, 2.5, "three", null, false]`, List(1, 2.5, "three", nil, false))
+	ConfirmUnmarshal(`[1, [2, [3]]]`, List(1, List(2, List(3))))
+	ConfirmUnmarshal(`[{"cycle": [1, 2]}]`, List(Loop(1, 2)))
+
+	var l LinearList
+	if e := json.Unmarshal([]byte(`[1]`), &l); e != nil || !l.Equal(List(1)) {
+		t.Fatalf("Unmarshal() into a zero LinearList should be %v not %v: %v", List(1), l, e)
+	}
+
+	c := Loop()
+	switch e := json.Unmarshal([]byte(`{"cycle": [1, 2, 3]}`), c); {
+	case e != nil:				t.Fatalf("Unmarshal() failed: %v", e)
+	case !c.Equal(Loop(1, 2, 3)):	t.Fatalf("Unmarshal() should be %v but is %v", Loop(1, 2, 3), c)
+	case c.Validate() != nil:	t.Fatalf("Unmarshal() should produce a valid loop: %v", c.Validate())
+	}
+
+	RefuteUnmarshal := func(s string, v interface{}) {
+		if e := json.Unmarshal([]byte(s), v); e == nil {
+			t.Fatalf("Unmarshal(%v) into %T should fail", s, v)
+		}
+	}
+	RefuteUnmarshal(`{"cycle": [1]}`, List())
+	RefuteUnmarshal(`[1]`, Loop())
+	RefuteUnmarshal(`[1`, List())
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	ConfirmRoundTrip := func(v interface{ Equal(interface{}) bool }) {
+		b, e := json.Marshal(v)
+		if e != nil {
+			t.Fatalf("Marshal(%v) failed: %v", v, e)
+		}
+		if r, e := DecodeJSON(b, NUMBERS_AS_WRITTEN); e != nil {
+			t.Fatalf("DecodeJSON(%v) failed: %v", string(b), e)
+		} else if !v.Equal(r) {
+			t.Fatalf("DecodeJSON(%v) should be %v but is %v", string(b), v, r)
+		}
+	}
+	ConfirmRoundTrip(List(1, 2.0, -3.5e20, "a", List(4, Loop(5.0))))
+	ConfirmRoundTrip(Loop(List(1), 2))
+}
+
+func TestDecodeJSONNumbers(t *testing.T) {
+	ConfirmDecode := func(s string, mode NumberMode, r *LinearList) {
+		if v, e := DecodeJSON([]byte(s), mode); e != nil {
+			t.Fatalf("DecodeJSON(%v) failed: %v", s, e)
+		} else if !r.Equal(v) {
+			t.Fatalf("DecodeJSON(%v) should be %v but is %v", s, r, v)
+		}
+	}
+	ConfirmDecode(`[1, 2.0, 3e2]`, NUMBERS_AS_WRITTEN, List(1, 2.0, 300.0))
+	ConfirmDecode(`[1, 2.0, 3e2]`, NUMBERS_AS_FLOAT64, List(1.0, 2.0, 300.0))
+	ConfirmDecode(`[1, [2]]`, NUMBERS_AS_INT, List(1, List(2)))
+	if _, e := DecodeJSON([]byte(`[1, 2.5]`), NUMBERS_AS_INT); e == nil {
+		t.Fatalf("DecodeJSON() should refuse 2.5 as an int")
+	}
+
+	v, e := DecodeJSON([]byte(`{"a": [1], "b": 2}`), NUMBERS_AS_WRITTEN)
+	if m, ok := v.(map[string]interface{}); e != nil || !ok || !List(1).Equal(m["a"]) || m["b"] != 2 {
+		t.Fatalf("DecodeJSON() should decode objects into maps not %v: %v", v, e)
+	}
+	if v, _ := DecodeJSON([]byte(`{"cycle": []}`), NUMBERS_AS_WRITTEN); reflect.TypeOf(v) != reflect.TypeOf(Loop()) {
+		t.Fatalf("DecodeJSON() should decode a cycle as a CycList not %T", v)
+	}
+}
+
+func TestUnmarshalJSONNumbers(t *testing.T) {
+	ConfirmUnmarshal := func(s string, mode NumberMode, r *LinearList) {
+		l := List()
+		l.UseNumberMode(mode)
+		if e := json.Unmarshal([]byte(s), l); e != nil {
+			t.Fatalf("Unmarshal(%v) failed: %v", s, e)
+		} else if !l.Equal(r) {
+			t.Fatalf("Unmarshal(%v) should be %v but is %v", s, r, l)
+		}
+	}
+	ConfirmUnmarshal(`[1, 2.0]`, NUMBERS_AS_WRITTEN, List(1, 2.0))
+	ConfirmUnmarshal(`[1, [2.0]]`, NUMBERS_AS_FLOAT64, List(1.0, List(2.0)))
+	ConfirmUnmarshal(`[1, [2]]`, NUMBERS_AS_INT, List(1, List(2)))
+
+	l := List()
+	l.UseNumberMode(NUMBERS_AS_INT)
+	if e := json.Unmarshal([]byte(`[2.5]`), l); e == nil {
+		t.Fatalf("Unmarshal() should refuse 2.5 as an int")
+	}
+
+	c := Loop()
+	c.UseNumberMode(NUMBERS_AS_FLOAT64)
+	if e := json.Unmarshal([]byte(`{"cycle": [1, 2]}`), c); e != nil || !c.Equal(Loop(1.0, 2.0)) {
+		t.Fatalf("Unmarshal() should be %v but is %v: %v", Loop(1.0, 2.0), c, e)
+	}
+	if e := json.Unmarshal([]byte(`{"cycle": [3]}`), c); e != nil || !c.Equal(Loop(3.0)) {
+		t.Fatalf("Unmarshal() should keep the number mode of %v: %v", c, e)
+	}
+}
+
+func TestUnmarshalJSONNestedNumbers(t *testing.T) {
+	l := List()
+	l.UseNumberMode(NUMBERS_AS_FLOAT64)
+	if e := json.Unmarshal([]byte(`[1, [2, {"cycle": [3]}]]`), l); e != nil {
+		t.Fatalf("Unmarshal() failed: %v", e)
+	}
+	inner, ok := l.At(1).(*LinearList)
+	if !ok {
+		t.Fatalf("Unmarshal() should decode a nested array as a LinearList not %T", l.At(1))
+	}
+	if e := json.Unmarshal([]byte(`[4, [5]]`), inner); e != nil || !inner.Equal(List(4.0, List(5.0))) {
+		t.Fatalf("a nested list should keep the number mode of its parent and give (4.0 (5.0)) not %v: %v", inner, e)
+	}
+	deep := l.At(1).(*LinearList).At(1).(*LinearList)
+	if e := json.Unmarshal([]byte(`[6]`), deep); e != nil || !deep.Equal(List(6.0)) {
+		t.Fatalf("a deeply nested list should keep the number mode of its parent and give (6.0) not %v: %v", deep, e)
+	}
+
+	v, e := DecodeJSON([]byte(`{"cycle": [[1]]}`), NUMBERS_AS_FLOAT64)
+	c, ok := v.(*CycList)
+	if e != nil || !ok {
+		t.Fatalf("DecodeJSON() should give a CycList not %v: %v", v, e)
+	}
+	inner = c.At(0).(*LinearList)
+	if e := json.Unmarshal([]byte(`[2]`), inner); e != nil || !inner.Equal(List(2.0)) {
+		t.Fatalf("a list decoded by DecodeJSON() should keep its number mode and give (2.0) not %v: %v", inner, e)
+	}
+}
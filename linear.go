@@ -48,13 +48,13 @@ func (l *LinearList) Delete(from, to int) {
 		last_element_index := l.length - 1
 		switch {
 		case from == 0:						switch {
-											case to == 0:					l.start = chain.Next(l.start)
-																			l.length--
-
 											case to == last_element_index:	l.start = nil
 																			l.end = nil
 																			l.length = 0
 
+											case to == 0:					l.start = chain.Next(l.start)
+																			l.length--
+
 											default:						l.start = l.findNode(to + 1)
 																			l.length -= to + 1
 											}
@@ -76,6 +76,7 @@ func (l *LinearList) Delete(from, to int) {
 											e.Link(chain.NEXT_NODE, e.MoveTo(to - from + 2))
 											l.length -= to - from + 1
 		}
+		l.invalidate(from)
 	}
 }
 
@@ -97,10 +98,12 @@ func (l *LinearList) Cut(start, end int) (r LinearList) {
 												}
 
 			case start == last_element_index:	r.start = l.end
+												r.end = l.end
 												l.end = l.findNode(start - 1)
 
-			case end == last_element_index:		l.end = l.findNode(start - 1)
-												r.start = l.findNode(start)
+			case end == last_element_index:		r.start = l.findNode(start)
+												r.end = l.end
+												l.end = l.findNode(start - 1)
 
 			case start == end:					s := l.findNode(start - 1)
 												r.start = l.findNode(start)
@@ -112,7 +115,6 @@ func (l *LinearList) Cut(start, end int) (r LinearList) {
 												r.end = l.findNode(end)
 												s.Link(chain.NEXT_NODE, l.findNode(end + 1))
 			}
-			l.cache.Clear()
 			if r.end != nil {
 				r.end.Link(chain.NEXT_NODE, nil)
 			}
@@ -122,6 +124,7 @@ func (l *LinearList) Cut(start, end int) (r LinearList) {
 				l.end.Link(chain.NEXT_NODE, nil)
 			}
 			l.length -= r.length
+			l.invalidate(start)
 		}
 	}
 	return
@@ -145,6 +148,7 @@ func (l *LinearList) Insert(i int, o interface{}) {
 										chain.Next(n1).Link(chain.NEXT_NODE, n2)
 										l.length++
 		}
+		l.invalidate(i)
 	}
 }
 
@@ -154,6 +158,12 @@ func (l *LinearList) Absorb(i int, o *LinearList) (ok bool) {
 		switch {
 		case l == nil:					*l = *o
 
+		case o.length == 0:
+
+		case l.length == 0:				l.start = o.start
+										l.end = o.end
+										l.length = o.length
+
 		case i == 0:					o.end.Link(chain.NEXT_NODE, l.start)
 										l.start = o.start
 										l.length += o.length
@@ -167,6 +177,7 @@ func (l *LinearList) Absorb(i int, o *LinearList) (ok bool) {
 										n.Link(chain.NEXT_NODE, o.start)
 										l.length += o.length
 		}
+		l.invalidate(i)
 		o.Erase()
 	 	ok = true
 	}
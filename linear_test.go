@@ -66,8 +66,9 @@ func TestLinearListEach(t *testing.T) {
 func TestLinearListReverse(t *testing.T) {
 	ConfirmReverse := func(l, r *LinearList) {
 		l.Reverse()
-		if !r.Equal(l) {
-			t.Fatalf("'%v' should be '%v'", l, r)
+		switch {
+		case !r.Equal(l):						t.Fatalf("'%v' should be '%v'", l, r)
+		case chain.Next(l.End()) != nil:		t.Fatalf("'%v' should end with a nil link", l)
 		}
 	}
 	l := List(1)
@@ -212,6 +213,7 @@ func TestLinearListDelete(t *testing.T) {
 		switch {
 		case !l.Equal(r):			t.Fatalf("Delete(%v, %v) should be '%v' and not '%v'", from, to, r, l)
 		case l.Len() != r.Len():	t.Fatalf("Delete(%v, %v) length be '%v' and not '%v'", from, to, r.Len(), l.Len())
+		case l.Validate() != nil:	t.Fatalf("Delete(%v, %v) should leave a valid list: %v", from, to, l.Validate())
 		}
 	}
 	ConfirmDelete(List(0, 1, 2, 3), -1, 0, List(1, 2, 3))
@@ -219,6 +221,7 @@ func TestLinearListDelete(t *testing.T) {
 	ConfirmDelete(List(0, 1, 2, 3), 0, 4, List())
 	ConfirmDelete(List(0, 1, 2, 3), 4, 0, List(0, 1, 2, 3))
 
+	ConfirmDelete(List(0), 0, 0, List())
 	ConfirmDelete(List(0, 1, 2, 3), 0, 0, List(1, 2, 3))
 	ConfirmDelete(List(0, 1, 2, 3), 0, 1, List(2, 3))
 	ConfirmDelete(List(0, 1, 2, 3), 0, 2, List(3))
@@ -231,6 +234,12 @@ func TestLinearListDelete(t *testing.T) {
 	ConfirmDelete(List(0, 1, 2, 3), 1, 1, List(0, 2, 3))
 	ConfirmDelete(List(0, 1, 2, 3), 1, 2, List(0, 3))
 	ConfirmDelete(List(0, 1, 2, 3), 2, 2, List(0, 1, 3))
+
+	l := List(0)
+	l.Delete(0, 0)
+	if l.Append(1); !l.Equal(List(1)) || l.End().Content() != 1 {
+		t.Fatalf("Delete(0, 0) of a single element should leave an empty list to append to: %v", l)
+	}
 }
 
 func TestLinearListCut(t *testing.T) {
@@ -268,6 +277,21 @@ func TestLinearListCut(t *testing.T) {
 	ConfirmCut(List(0, 1, 2, 3), 3, 3, List(3), List(0, 1, 2))
 }
 
+func TestLinearListCutEnd(t *testing.T) {
+	ConfirmCutEnd := func(l *LinearList, from, to int, r1, r2 *LinearList) {
+		x := l.Cut(from, to)
+		x.Append(-1)
+		l.Append(-2)
+		switch {
+		case !x.Equal(r1):			t.Fatalf("Cut(%v, %v) cut should be '%v' and not '%v'", from, to, r1, x)
+		case !l.Equal(r2):			t.Fatalf("Cut(%v, %v) remainder should be '%v' and not '%v'", from, to, r2, l)
+		}
+	}
+	ConfirmCutEnd(List(0, 1, 2, 3), 3, 3, List(3, -1), List(0, 1, 2, -2))
+	ConfirmCutEnd(List(0, 1, 2, 3), 2, 3, List(2, 3, -1), List(0, 1, -2))
+	ConfirmCutEnd(List(0, 1, 2, 3), 1, 2, List(1, 2, -1), List(0, 3, -2))
+}
+
 func TestLinearListInsert(t *testing.T) {
 	ConfirmInsert := func(l *LinearList, i int, v interface{}, r *LinearList) {
 		l.Insert(i, v)
@@ -327,6 +351,23 @@ func TestLinearListAbsorb(t *testing.T) {
 	ConfirmAbsorb(List(0, 1, 2, 3), 3, List(-3, -2, -1), List(0, 1, 2, -3, -2, -1, 3))
 	ConfirmAbsorb(List(0, 1, 2, 3), 4, List(-3, -2, -1), List(0, 1, 2, 3, -3, -2, -1))
 	RefuteAbsorb(List(0, 1, 2, 3), 5, List(-3, -2, -1), List(0, 1, 2, 3))
+
+	ConfirmAbsorb(List(0, 1), 0, List(), List(0, 1))
+	ConfirmAbsorb(List(0, 1), 1, List(), List(0, 1))
+	ConfirmAbsorb(List(0, 1), 2, List(), List(0, 1))
+	ConfirmAbsorb(List(), 0, List(), List())
+
+	l := List(0, 1)
+	l.Absorb(2, List())
+	if l.Append(2); !l.Equal(List(0, 1, 2)) {
+		t.Fatalf("Absorb(2, ...) of an empty list should leave %v unchanged", l)
+	}
+
+	l = List()
+	l.Absorb(0, List(0, 1))
+	if l.Append(2); !l.Equal(List(0, 1, 2)) {
+		t.Fatalf("Absorb(0, ...) into an empty list should leave an end to append to: %v", l)
+	}
 }
 
 func TestLinearListCompact(t *testing.T) {
@@ -354,18 +395,22 @@ func TestLinearListCompact(t *testing.T) {
 }
 
 func TestLinearListExpand(t * testing.T) {
-/*	ConfirmExpand := func(l *LinearList, i, n int, r *LinearList) {
+	ConfirmExpand := func(l *LinearList, i, n int, r *LinearList) {
 		l.Expand(i, n)
-		if !r.Equal(l) {
-			t.Fatalf("Expand(%v, %v) should be %v but is %v", i, n, r, l)
+		switch {
+		case !r.Equal(l):			t.Fatalf("Expand(%v, %v) should be %v but is %v", i, n, r, l)
+		case l.Len() != r.Len():	t.Fatalf("Expand(%v, %v) length should be %v not %v", i, n, r.Len(), l.Len())
+		}
+		if e := l.Validate(); e != nil {
+			t.Fatalf("Expand(%v, %v) should leave a valid list: %v", i, n, e)
 		}
 	}
 
-	t.Fatal("Fix these tests")
 	ConfirmExpand(List(), 0, 3, List(nil, nil, nil))
+	ConfirmExpand(List(0, 1, 2, 3), 0, 2, List(nil, nil, 0, 1, 2, 3))
 	ConfirmExpand(List(0, 1, 2, 3), 1, 2, List(0, nil, nil, 1, 2, 3))
+	ConfirmExpand(List(0, 1, 2, 3), 4, 2, List(0, 1, 2, 3, nil, nil))
 	ConfirmExpand(List(0, 1, 2, 3), 5, 2, List(0, 1, 2, 3))
-*/
 }
 
 func TestLinearListStart(t *testing.T) {
@@ -411,4 +456,61 @@ func TestLinearListTail(t *testing.T) {
 	ConfirmTail(List(0), List())
 	ConfirmTail(List(0, 1), List(1))
 	ConfirmTail(List(0, 1, 2), List(1, 2))
-}
\ No newline at end of file
+}
+
+func TestLinearListAll(t *testing.T) {
+	l := List(10, 11, 12, 13, 14)
+	count := 0
+	for i, v := range l.All() {
+		if i != count || v != i + 10 {
+			t.Fatalf("element %v erroneously reported as %v: %v", count, i, v)
+		}
+		count++
+	}
+	if count != l.Len() {
+		t.Fatalf("list length %v erroneously reported iterations as %v", l.Len(), count)
+	}
+
+	count = 0
+	for i := range l.All() {
+		if i == 2 {
+			break
+		}
+		count++
+	}
+	if count != 2 {
+		t.Fatalf("break should terminate iteration after 2 elements not %v", count)
+	}
+}
+
+func TestLinearListValues(t *testing.T) {
+	l := List(0, 1, 2, 3, 4)
+	count := 0
+	for v := range l.Values() {
+		if v != count {
+			t.Fatalf("element %v erroneously reported as %v", count, v)
+		}
+		count++
+	}
+	if count != l.Len() {
+		t.Fatalf("list length %v erroneously reported iterations as %v", l.Len(), count)
+	}
+}
+
+func TestLinearListBackward(t *testing.T) {
+	l := List(0, 1, 2, 3, 4)
+	count := l.Len()
+	for i, v := range l.Backward() {
+		count--
+		if i != count || v != count {
+			t.Fatalf("element %v erroneously reported as %v: %v", count, i, v)
+		}
+	}
+	if count != 0 {
+		t.Fatalf("backward iteration should visit %v elements", l.Len())
+	}
+
+	for range List().Backward() {
+		t.Fatalf("empty list should not be iterated")
+	}
+}
@@ -0,0 +1,332 @@
+package lists
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"github.com/feyeleanor/chain"
+)
+
+/*
+	Parse reads the notation produced by String() back into lists.
+
+	A parenthesised sequence of terms becomes a LinearList, unless its final term is ... in
+	which case it becomes a CycList. Terms may be nested lists, nil, true, false, integers,
+	floating point numbers, double-quoted strings using Go escape sequences, or symbols. Any
+	other run of characters is read as a Symbol, and a Symbol which would otherwise be read as
+	something else, or which contains a delimiter, is written between bars as |nil| with any
+	bar or backslash in it escaped by a backslash.
+
+	A term prefixed with a label #n= may be referred to later in the input as #n#, in which
+	case the same list is shared by each reference. This allows lists which contain
+	themselves to be written and read.
+
+	An empty list is written as () and an empty loop as (...), so that both read back as empty
+	lists of the same kind. Infinite and NaN floating point numbers are written as +Inf, -Inf
+	and NaN, and a Symbol with one of those names is written between bars.
+*/
+
+//	A Symbol is a bare word appearing in list notation
+type Symbol string
+
+//	Writes the symbol so that Parse() reads it back as the same Symbol
+func (s Symbol) String() (t string) {
+	if t = string(s); needsBars(t) {
+		t = "|" + strings.NewReplacer(`\`, `\\`, "|", `\|`).Replace(t) + "|"
+	}
+	return
+}
+
+//	Determines whether a symbol would be read as something other than itself when written as a bare word
+func needsBars(s string) bool {
+	switch {
+	case s == "", s == "nil", s == "true", s == "false", s == "...", isNonFinite(s):
+	case s[0] == '|', isLabel(s, '='), isLabel(s, '#'), looksNumeric(s):
+	case strings.IndexFunc(s, isDelimiter) != -1:
+	default:
+		return false
+	}
+	return true
+}
+
+//	A ParseError reports where in its input Parse was unable to continue
+type ParseError struct {
+	Line		int
+	Column		int
+	Message		string
+}
+
+func (e ParseError) Error() string {
+	return fmt.Sprintf("line %v, column %v: %v", e.Line, e.Column, e.Message)
+}
+
+//	Reads a single term, which is usually a list, from its textual representation
+func Parse(s string) (r interface{}, e error) {
+	p := &parser{ input: []rune(s), line: 1, column: 1, labels: make(map[int]interface{}), defined: make(map[int]bool) }
+	if r, e = p.term(); e == nil {
+		p.skipSpace()
+		if p.more() {
+			e = p.error("unexpected %q after term", p.peek())
+		} else if len(p.labels) > 0 {
+			p.resolve(r, make(map[*ListHeader]bool))
+		}
+	}
+	return
+}
+
+//	Reads a single term from the textual representation provided by r
+func Read(r io.Reader) (v interface{}, e error) {
+	var b []byte
+	if b, e = io.ReadAll(r); e == nil {
+		v, e = Parse(string(b))
+	}
+	return
+}
+
+type parser struct {
+	input		[]rune
+	position	int
+	line		int
+	column		int
+	labels		map[int]interface{}
+	defined		map[int]bool
+}
+
+func (p *parser) error(format string, v... interface{}) error {
+	return ParseError{ p.line, p.column, fmt.Sprintf(format, v...) }
+}
+
+func (p *parser) more() bool {
+	return p.position < len(p.input)
+}
+
+func (p *parser) peek() rune {
+	return p.input[p.position]
+}
+
+func (p *parser) next() (r rune) {
+	r = p.input[p.position]
+	p.position++
+	if r == '\n' {
+		p.line++
+		p.column = 1
+	} else {
+		p.column++
+	}
+	return
+}
+
+func (p *parser) skipSpace() {
+	for p.more() && unicode.IsSpace(p.peek()) {
+		p.next()
+	}
+}
+
+func isDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || r == '(' || r == ')' || r == '"'
+}
+
+func (p *parser) term() (r interface{}, e error) {
+	p.skipSpace()
+	line, column := p.line, p.column
+	switch {
+	case !p.more():				e = p.error("unexpected end of input")
+	default:					if r, e = p.element(); r == cycleMarker {
+									e = ParseError{ line, column, "... must be the final term of a list" }
+								}
+	}
+	return
+}
+
+func (p *parser) element() (r interface{}, e error) {
+	switch p.peek() {
+	case '(':					r, e = p.list()
+	case ')':					e = p.error("unexpected )")
+	case '"':					r, e = p.quoted()
+	case '|':					r, e = p.symbol()
+	default:					r, e = p.atom()
+	}
+	return
+}
+
+type marker struct{}
+
+var cycleMarker = &marker{}
+
+//	A reference to a labelled term which is resolved once parsing is complete
+type labelReference struct {
+	label		int
+}
+
+func (p *parser) list() (r interface{}, e error) {
+	var v interface{}
+
+	items := []interface{}{}
+	cyclic := false
+	line, column := p.line, p.column
+	p.next()
+	for {
+		p.skipSpace()
+		switch {
+		case !p.more():				return nil, ParseError{ line, column, "unterminated list" }
+		case p.peek() == ')':		p.next()
+									if cyclic {
+										c := NewCycList(&chain.Cell{})
+										c.Concatenate(items)
+										return c, nil
+									}
+									l := NewLinearList(&chain.Cell{})
+									l.Concatenate(items)
+									return l, nil
+		case cyclic:				return nil, p.error("... must be the final term of a list")
+		default:					if v, e = p.element(); v == cycleMarker {
+										cyclic = true
+										continue
+									}
+		}
+		if e != nil {
+			return
+		}
+		items = append(items, v)
+	}
+}
+
+//	Reads a labelled term, written #n=term, recording it so that later references to #n# share it
+func (p *parser) labelled(label int) (r interface{}, e error) {
+	line, column := p.line, p.column
+	switch {
+	case !p.more():					e = p.error("missing term for label #%v=", label)
+	case isDelimiter(p.peek()) && p.peek() != '(' && p.peek() != '"':
+									e = p.error("missing term for label #%v=", label)
+	default:						p.defined[label] = true
+									if r, e = p.element(); e == nil {
+										if _, ok := r.(*labelReference); ok || r == cycleMarker {
+											e = ParseError{ line, column, fmt.Sprintf("invalid term for label #%v=", label) }
+										}
+										p.labels[label] = r
+									}
+	}
+	return
+}
+
+//	Replaces references to labelled terms with the terms themselves
+func (p *parser) resolve(v interface{}, visited map[*ListHeader]bool) {
+	if h := headerOf(v); h != nil && !visited[h] {
+		visited[h] = true
+		h.eachNode(func(i int, n chain.Node) {
+			x := n.Content()
+			if ref, ok := x.(*labelReference); ok {
+				x = p.labels[ref.label]
+				n.Set(chain.CURRENT_NODE, x)
+			}
+			p.resolve(x, visited)
+		})
+	}
+}
+
+func (p *parser) quoted() (r interface{}, e error) {
+	line, column := p.line, p.column
+	start := p.position
+	p.next()
+	for escaped := false; ; {
+		switch {
+		case !p.more():				return nil, ParseError{ line, column, "unterminated string" }
+		case escaped:				escaped = false
+		case p.peek() == '\\':		escaped = true
+		case p.peek() == '"':		p.next()
+									if r, e = strconv.Unquote(string(p.input[start:p.position])); e != nil {
+										e = ParseError{ line, column, "invalid string " + string(p.input[start:p.position]) }
+									}
+									return
+		}
+		p.next()
+	}
+}
+
+//	Reads a symbol written between bars, in which a backslash escapes the following character
+func (p *parser) symbol() (r interface{}, e error) {
+	line, column := p.line, p.column
+	text := []rune{}
+	p.next()
+	for escaped := false; ; {
+		switch {
+		case !p.more():				return nil, ParseError{ line, column, "unterminated symbol" }
+		case escaped:				escaped = false
+									text = append(text, p.peek())
+		case p.peek() == '\\':		escaped = true
+		case p.peek() == '|':		p.next()
+									return Symbol(text), nil
+		default:					text = append(text, p.peek())
+		}
+		p.next()
+	}
+}
+
+func (p *parser) atom() (r interface{}, e error) {
+	line, column := p.line, p.column
+	start := p.position
+	for p.more() && !isDelimiter(p.peek()) {
+		p.next()
+	}
+	s := string(p.input[start:p.position])
+	switch {
+	case s == "nil":
+	case s == "true":				r = true
+	case s == "false":				r = false
+	case s == "...":				r = cycleMarker
+	case isNonFinite(s):			r, _ = strconv.ParseFloat(s, 64)
+	case isLabel(s, '='):			r, e = p.labelled(labelNumber(s))
+	case isLabel(s, '#'):			if n := labelNumber(s); p.defined[n] {
+										r = &labelReference{ n }
+									} else {
+										e = ParseError{ line, column, "undefined label " + s }
+									}
+	case looksNumeric(s):			if i, e := strconv.Atoi(s); e == nil {
+										r = i
+									} else if f, e := strconv.ParseFloat(s, 64); e == nil {
+										r = f
+									} else {
+										r = Symbol(s)
+									}
+	default:						r = Symbol(s)
+	}
+	return
+}
+
+//	Determines whether a word is a label definition #n= or label reference #n#
+func isLabel(s string, suffix byte) bool {
+	if n := len(s); n > 2 && s[0] == '#' && s[n - 1] == suffix {
+		for _, r := range s[1:n - 1] {
+			if r < '0' || r > '9' {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func labelNumber(s string) (n int) {
+	n, _ = strconv.Atoi(s[1:len(s) - 1])
+	return
+}
+
+//	Determines whether a word is one of the forms in which String() writes an infinite or NaN floating point number
+func isNonFinite(s string) bool {
+	return s == "+Inf" || s == "-Inf" || s == "NaN"
+}
+
+//	Determines whether a word starts like a number, so that words such as Inf and nan are read as symbols
+func looksNumeric(s string) bool {
+	for i, r := range s {
+		switch {
+		case unicode.IsDigit(r):					return true
+		case i == 0 && (r == '+' || r == '-'):
+		case r == '.':
+		default:									return false
+		}
+	}
+	return false
+}
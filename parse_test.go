@@ -0,0 +1,167 @@
+package lists
+
+import "math"
+import "strings"
+import "testing"
+
+func TestParse(t *testing.T) {
+	ConfirmParse := func(s string, r interface{}) {
+		x, e := Parse(s)
+		switch {
+		case e != nil:			t.Fatalf("Parse(%q) failed: %v", s, e)
+		case r == nil:			if x != nil {
+									t.Fatalf("Parse(%q) should be nil but is %v", s, x)
+								}
+		case !r.(Equatable).Equal(x):	t.Fatalf("Parse(%q) should be %v but is %v", s, r, x)
+		}
+	}
+	ConfirmParse("()", List())
+	ConfirmParse("(1)", List(1))
+	ConfirmParse(" ( 1\t2\n3 ) ", List(1, 2, 3))
+	ConfirmParse("(1 -2 +3 4.5 -0.25 1e3)", List(1, -2, 3, 4.5, -0.25, 1000.0))
+	ConfirmParse("(nil a \"b c\" \"d\\\"e\")", List(nil, Symbol("a"), "b c", "d\"e"))
+	ConfirmParse("(1 (2 3) ((4)))", List(1, List(2, 3), List(List(4))))
+	ConfirmParse("(1 2 ...)", Loop(1, 2))
+	ConfirmParse("(0 (1 ...) 2)", List(0, Loop(1), 2))
+	ConfirmParse("(...)", Loop())
+	ConfirmParse("nil", nil)
+	ConfirmParse("(Inf |NaN| - . inf)", List(Symbol("Inf"), Symbol("NaN"), Symbol("-"), Symbol("."), Symbol("inf")))
+	ConfirmParse("(+Inf -Inf)", List(math.Inf(1), math.Inf(-1)))
+	ConfirmParse("(true false)", List(true, false))
+	ConfirmParse("(|nil| |a b| |\\|\\\\| a|b)", List(Symbol("nil"), Symbol("a b"), Symbol("|\\"), Symbol("a|b")))
+	ConfirmParse("(() (...))", List(List(), Loop()))
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	ConfirmRoundTrip := func(l Equatable) {
+		s := l.(interface{ String() string }).String()
+		if x, e := Parse(s); e != nil {
+			t.Fatalf("Parse(%q) failed: %v", s, e)
+		} else if !l.Equal(x) {
+			t.Fatalf("Parse(%q) should be %v but is %v", s, l, x)
+		}
+	}
+	ConfirmRoundTrip(List())
+	ConfirmRoundTrip(List(0, nil, 1.0, -2.5, "x", "(y)", Symbol("z")))
+	ConfirmRoundTrip(List(1, List(0, nil), Loop(2, 3)))
+	ConfirmRoundTrip(Loop(10, Loop(0, Loop(0))))
+	ConfirmRoundTrip(Loop("a b", List("c")))
+	ConfirmRoundTrip(List(List()))
+	ConfirmRoundTrip(List(List(), Loop(), Loop(List())))
+	ConfirmRoundTrip(List(true, false, Symbol("true"), Symbol("false")))
+	ConfirmRoundTrip(List(Symbol("nil"), Symbol("..."), Symbol(""), Symbol("12"), Symbol("-1.5")))
+	ConfirmRoundTrip(List(Symbol("#1="), Symbol("#1#"), Symbol("a b"), Symbol("(c)"), Symbol("\"d\"")))
+	ConfirmRoundTrip(List(Symbol("|x|"), Symbol("a|b"), Symbol("\\")))
+	ConfirmRoundTrip(Loop())
+	ConfirmRoundTrip(List(math.Inf(1), math.Inf(-1), Symbol("+Inf"), Symbol("-Inf"), Symbol("NaN"), Symbol("Inf")))
+
+	if x, e := Parse(Loop().String()); e != nil || !isLoop(x) {
+		t.Fatalf("Parse() of an empty loop should give a CycList not %v: %v", x, e)
+	}
+	if x, e := Parse(List(math.NaN()).String()); e != nil {
+		t.Fatalf("Parse() of NaN failed: %v", e)
+	} else if f, ok := x.(*LinearList).At(0).(float64); !ok || !math.IsNaN(f) {
+		t.Fatalf("Parse() of NaN should give NaN not %v", x)
+	}
+}
+
+func TestSymbolString(t *testing.T) {
+	ConfirmString := func(s Symbol, x string) {
+		if r := s.String(); r != x {
+			t.Fatalf("Symbol(%q) should be written as %v not %v", string(s), x, r)
+		}
+	}
+	ConfirmString("a", "a")
+	ConfirmString("a|b", "a|b")
+	ConfirmString("nil", "|nil|")
+	ConfirmString("true", "|true|")
+	ConfirmString("", "||")
+	ConfirmString("1", "|1|")
+	ConfirmString("a b", "|a b|")
+	ConfirmString("|a\\", "|\\|a\\\\|")
+	ConfirmString("NaN", "|NaN|")
+	ConfirmString("+Inf", "|+Inf|")
+	ConfirmString("Inf", "Inf")
+}
+
+func TestParseError(t *testing.T) {
+	ConfirmError := func(s string, line, column int) {
+		_, e := Parse(s)
+		if p, ok := e.(ParseError); !ok {
+			t.Fatalf("Parse(%q) should fail with a ParseError not %v", s, e)
+		} else if p.Line != line || p.Column != column {
+			t.Fatalf("Parse(%q) error should be at %v:%v not %v", s, line, column, p)
+		}
+	}
+	ConfirmError("", 1, 1)
+	ConfirmError("(1 2", 1, 1)
+	ConfirmError("(1\n  (2", 2, 3)
+	ConfirmError(")", 1, 1)
+	ConfirmError("(1) 2", 1, 5)
+	ConfirmError("(1 ... 2)", 1, 8)
+	ConfirmError("...", 1, 1)
+	ConfirmError("(\"abc", 1, 2)
+	ConfirmError("(\"\\q\")", 1, 2)
+	ConfirmError("(|abc)", 1, 2)
+}
+
+func TestRead(t *testing.T) {
+	if x, e := Read(strings.NewReader("(1 (2) ...)")); e != nil || !Loop(1, List(2)).Equal(x) {
+		t.Fatalf("Read() should be %v but is %v: %v", Loop(1, List(2)), x, e)
+	}
+}
+
+func TestParseLabels(t *testing.T) {
+	x, e := Parse("#1=(a #1#)")
+	if e != nil {
+		t.Fatalf("Parse() failed: %v", e)
+	}
+	l := x.(*LinearList)
+	if l.At(1) != l {
+		t.Fatalf("%v should contain itself", l)
+	}
+
+	x, e = Parse("(#1=(1 2) #1# (#1#))")
+	if e != nil {
+		t.Fatalf("Parse() failed: %v", e)
+	}
+	l = x.(*LinearList)
+	if l.At(0) != l.At(1) || l.At(1) != l.At(2).(*LinearList).At(0) {
+		t.Fatalf("%v should share its sublists", l)
+	}
+
+	x, e = Parse("#1=(1 #1# ...)")
+	if c, ok := x.(*CycList); e != nil || !ok || c.At(1) != c {
+		t.Fatalf("%v should be a loop containing itself: %v", x, e)
+	}
+
+	ConfirmError := func(s string, line, column int) {
+		_, e := Parse(s)
+		if p, ok := e.(ParseError); !ok {
+			t.Fatalf("Parse(%q) should fail with a ParseError not %v", s, e)
+		} else if p.Line != line || p.Column != column {
+			t.Fatalf("Parse(%q) error should be at %v:%v not %v", s, line, column, p)
+		}
+	}
+	ConfirmError("(1 #1#)", 1, 4)
+	ConfirmError("(#1= 2)", 1, 5)
+}
+
+func TestParseSharedRoundTrip(t *testing.T) {
+	s := List(1)
+	x := List(0)
+	y := List(s, x, s)
+	x.Append(y)
+	text := y.String()
+	r, e := Parse(text)
+	if e != nil {
+		t.Fatalf("Parse(%q) failed: %v", text, e)
+	}
+	l := r.(*LinearList)
+	switch {
+	case !l.Equal(y):							t.Fatalf("Parse(%q) should be %v but is %v", text, y, l)
+	case l.String() != text:					t.Fatalf("Parse(%q) should print as %v not %v", text, text, l)
+	case l.At(0) != l.At(2):					t.Fatalf("Parse(%q) should share %v", text, l.At(0))
+	case l.At(1).(*LinearList).At(1) != l:		t.Fatalf("Parse(%q) should contain itself", text)
+	}
+}
@@ -0,0 +1,115 @@
+package lists
+
+import "github.com/feyeleanor/chain"
+
+/*
+	A RingBuffer holds at most a fixed number of elements in a CycList whose nodes are all
+	allocated when the RingBuffer is created. Put stores an element in the node following the
+	newest and Get takes the oldest, so once created a RingBuffer allocates no further nodes.
+	The RingBuffer advances around the loop by following the Tail links of its chain.Cell nodes
+	directly, as stepping with chain.Next copies the Cell and so would allocate.
+
+	When a full RingBuffer is given another element its RingPolicy determines whether the
+	oldest element is overwritten or the new element is refused with a RingFull error.
+*/
+
+type RingPolicy int
+
+const (
+	OVERWRITE_OLDEST RingPolicy = iota
+	REJECT_NEWEST
+)
+
+//	RingFull reports an element refused by a full RingBuffer
+type RingFull struct{}
+
+func (e RingFull) Error() string {
+	return "lists: ring buffer full"
+}
+
+type RingBuffer struct {
+	loop		CycList
+	policy		RingPolicy
+	oldest		*chain.Cell
+	free		*chain.Cell
+	length		int
+}
+
+//	Creates a RingBuffer with space for n elements
+func NewRingBuffer(n int, policy RingPolicy) (r *RingBuffer) {
+	if n < 1 {
+		n = 1
+	}
+	r = &RingBuffer{ loop: *NewCycList(&chain.Cell{}), policy: policy }
+	r.loop.Expand(0, n)
+	r.oldest = r.loop.start.(*chain.Cell)
+	r.free = r.oldest
+	return
+}
+
+func (r *RingBuffer) Len() int {
+	return r.length
+}
+
+func (r *RingBuffer) Cap() int {
+	return r.loop.length
+}
+
+func (r *RingBuffer) Full() bool {
+	return r.length == r.loop.length
+}
+
+//	Adds v as the newest element, overwriting the oldest element or returning RingFull when the RingBuffer is full
+func (r *RingBuffer) Put(v interface{}) (e error) {
+	switch {
+	case !r.Full():					r.length++
+	case r.policy == REJECT_NEWEST:	return RingFull{}
+	default:						r.oldest = r.oldest.Tail
+	}
+	r.free.Head = v
+	r.free = r.free.Tail
+	return
+}
+
+//	Removes and returns the oldest element
+func (r *RingBuffer) Get() (v interface{}, ok bool) {
+	if ok = r.length > 0; ok {
+		v = r.oldest.Head
+		r.oldest.Head = nil
+		r.oldest = r.oldest.Tail
+		r.length--
+	}
+	return
+}
+
+//	Returns the oldest element without removing it
+func (r *RingBuffer) Peek() (v interface{}, ok bool) {
+	if ok = r.length > 0; ok {
+		v = r.oldest.Head
+	}
+	return
+}
+
+//	Calls f for each element from the oldest to the newest without removing them
+func (r *RingBuffer) Each(f func(interface{})) {
+	n := r.oldest
+	for i := r.length; i > 0; i-- {
+		f(n.Head)
+		n = n.Tail
+	}
+}
+
+//	Removes all elements, returning them in order from the oldest to the newest
+func (r *RingBuffer) Drain() (s []interface{}) {
+	s = make([]interface{}, 0, r.length)
+	for v, ok := r.Get(); ok; v, ok = r.Get() {
+		s = append(s, v)
+	}
+	return
+}
+
+func (r *RingBuffer) String() string {
+	l := List()
+	r.Each(func(v interface{}) { l.appendValue(v) })
+	return l.String()
+}
@@ -0,0 +1,102 @@
+package lists
+
+import "reflect"
+import "testing"
+
+func TestRingBuffer(t *testing.T) {
+	r := NewRingBuffer(3, OVERWRITE_OLDEST)
+	switch {
+	case r.Cap() != 3:			t.Fatalf("Cap() should be 3 not %v", r.Cap())
+	case r.Len() != 0:			t.Fatalf("Len() should be 0 not %v", r.Len())
+	case r.Full():				t.Fatalf("a new RingBuffer should not be full")
+	}
+	if _, ok := r.Get(); ok {
+		t.Fatalf("Get() should fail on an empty RingBuffer")
+	}
+
+	for i := 0; i < 3; i++ {
+		if e := r.Put(i); e != nil {
+			t.Fatalf("Put(%v) should succeed: %v", i, e)
+		}
+	}
+	switch {
+	case !r.Full():					t.Fatalf("%v should be full", r)
+	case r.String() != "(0 1 2)":	t.Fatalf("String() should be (0 1 2) not %v", r)
+	}
+
+	r.Put(3)
+	r.Put(4)
+	switch v, ok := r.Peek(); {
+	case r.Len() != 3:				t.Fatalf("Len() should be 3 not %v", r.Len())
+	case !ok || v != 2:				t.Fatalf("Put() should overwrite the oldest elements leaving 2 first not %v", v)
+	}
+	if v, ok := r.Get(); !ok || v != 2 {
+		t.Fatalf("Get() should return 2 not %v", v)
+	}
+	r.Put(5)
+	if s := r.Drain(); !reflect.DeepEqual(s, []interface{}{ 3, 4, 5 }) {
+		t.Fatalf("Drain() should return [3 4 5] not %v", s)
+	}
+	if r.Len() != 0 || len(r.Drain()) != 0 {
+		t.Fatalf("Drain() should empty the RingBuffer")
+	}
+	if e := r.loop.Validate(); e != nil || r.loop.Len() != 3 {
+		t.Fatalf("the RingBuffer should keep its loop of 3 nodes: %v", e)
+	}
+}
+
+func TestRingBufferReject(t *testing.T) {
+	r := NewRingBuffer(2, REJECT_NEWEST)
+	r.Put(0)
+	r.Put(1)
+	if e := r.Put(2); e != (RingFull{}) {
+		t.Fatalf("Put() should be refused when full not %v", e)
+	}
+	if r.String() != "(0 1)" {
+		t.Fatalf("a refused Put() should leave (0 1) not %v", r)
+	}
+	r.Get()
+	if e := r.Put(2); e != nil || r.String() != "(1 2)" {
+		t.Fatalf("Put() should succeed once there is space, not %v leaving %v", e, r)
+	}
+
+	if NewRingBuffer(0, REJECT_NEWEST).Cap() != 1 {
+		t.Fatalf("a RingBuffer should have space for at least one element")
+	}
+}
+
+func TestRingBufferAllocations(t *testing.T) {
+	r := NewRingBuffer(8, OVERWRITE_OLDEST)
+	v := &struct{}{}
+	if n := testing.AllocsPerRun(100, func() {
+		for i := 0; i < 20; i++ {
+			r.Put(v)
+			if i % 3 == 0 {
+				r.Get()
+			}
+		}
+	}); n != 0 {
+		t.Fatalf("Put() and Get() should not allocate, but made %v allocations", n)
+	}
+}
+
+func TestRingBufferNodes(t *testing.T) {
+	r := NewRingBuffer(3, OVERWRITE_OLDEST)
+	nodes := map[interface{}]bool{}
+	for _, n := range r.loop.nodes() {
+		nodes[n] = true
+	}
+	for i := 0; i < 10; i++ {
+		r.Put(i)
+		if i % 4 == 0 {
+			r.Get()
+		}
+		switch {
+		case !nodes[r.oldest]:		t.Fatalf("after %v puts the oldest element should be held by a node of the loop", i + 1)
+		case !nodes[r.free]:		t.Fatalf("after %v puts the next element should be stored in a node of the loop", i + 1)
+		}
+	}
+	if s := r.Drain(); !reflect.DeepEqual(s, []interface{}{ 7, 8, 9 }) {
+		t.Fatalf("Drain() should return [7 8 9] not %v", s)
+	}
+}
@@ -0,0 +1,156 @@
+package lists
+
+import "github.com/feyeleanor/chain"
+
+/*
+	Two loops holding the same elements in the same cyclic order describe the same cycle, even
+	when they start at different elements. These functions compare loops irrespective of where
+	they start and rotate a loop to a canonical starting point.
+*/
+
+//	Collects the nodes of the list in order from its start
+func (l *ListHeader) nodes() (r []chain.Node) {
+	r = make([]chain.Node, 0, l.length)
+	l.eachNode(func(i int, n chain.Node) {
+		r = append(r, n)
+	})
+	return
+}
+
+//	Compares the contents of two nodes in the same way as Equal compares the elements of lists
+func sameNode(a, b chain.Node) bool {
+	return newComparison(false, equalValues).values(a.Content(), b.Content())
+}
+
+//	Finds the smallest offset at which the list, read cyclically, matches o using the Knuth-Morris-Pratt algorithm
+func (l *ListHeader) rotationOffset(o *ListHeader) (k int, ok bool) {
+	switch {
+	case o == nil || l.length != o.length:	return
+	case l.length == 0:						return 0, true
+	}
+
+	text, pattern := l.nodes(), o.nodes()
+	n := len(pattern)
+	fail := make([]int, n)
+	for i, j := 1, 0; i < n; i++ {
+		for j > 0 && !sameNode(pattern[i], pattern[j]) {
+			j = fail[j - 1]
+		}
+		if sameNode(pattern[i], pattern[j]) {
+			j++
+		}
+		fail[i] = j
+	}
+
+	for i, j := 0, 0; i < 2 * n - 1; i++ {
+		t := text[i % n]
+		for j > 0 && !sameNode(t, pattern[j]) {
+			j = fail[j - 1]
+		}
+		if sameNode(t, pattern[j]) {
+			j++
+		}
+		if j == n {
+			return i - n + 1, true
+		}
+	}
+	return
+}
+
+//	Finds the offset of the lexicographically least rotation of the list using Booth's algorithm
+func (l *ListHeader) leastRotation(less func(a, b interface{}) bool) (k int) {
+	n := l.length
+	s := make([]interface{}, 0, n)
+	for v := range l.Values() {
+		s = append(s, v)
+	}
+	at := func(i int) interface{} {
+		return s[i % n]
+	}
+	compare := func(a, b interface{}) (r int) {
+		switch {
+		case less(a, b):		r = -1
+		case less(b, a):		r = 1
+		}
+		return
+	}
+
+	f := make([]int, 2 * n)
+	for i := range f {
+		f[i] = -1
+	}
+	for j := 1; j < 2 * n; j++ {
+		v := at(j)
+		i := f[j - k - 1]
+		for i != -1 && compare(v, at(k + i + 1)) != 0 {
+			if compare(v, at(k + i + 1)) < 0 {
+				k = j - i - 1
+			}
+			i = f[i]
+		}
+		if compare(v, at(k + i + 1)) != 0 {
+			if compare(v, at(k)) < 0 {
+				k = j
+			}
+			f[j - k] = -1
+		} else {
+			f[j - k] = i + 1
+		}
+	}
+	return k % n
+}
+
+
+//	Determines whether another loop holds the same elements in the same cyclic order, whatever their starting points
+func (c CycList) EquivalentRotation(o *CycList) (ok bool) {
+	if o != nil {
+		_, ok = c.rotationOffset(&o.ListHeader)
+	}
+	return
+}
+
+//	Returns the smallest offset k for which c.Rotate(k) would make the loop Equal to o.
+//	ok is false when no rotation of the loop matches o.
+func (c CycList) RotationOffset(o *CycList) (k int, ok bool) {
+	if o != nil {
+		k, ok = c.rotationOffset(&o.ListHeader)
+	}
+	return
+}
+
+//	Rotates the loop so that it starts with its lexicographically least rotation under less, returning the offset rotated by.
+//	Loops which are rotations of each other have the same canonical form, which makes them suitable for use as keys.
+func (c *CycList) Canonicalize(less func(a, b interface{}) bool) (k int) {
+	if c != nil && c.length > 1 {
+		k = c.leastRotation(less)
+		c.Rotate(k)
+	}
+	return
+}
+
+
+//	Determines whether another loop holds the same elements in the same cyclic order, whatever their starting points
+func (c DoubleLoop) EquivalentRotation(o *DoubleLoop) (ok bool) {
+	if o != nil {
+		_, ok = c.rotationOffset(&o.ListHeader)
+	}
+	return
+}
+
+//	Returns the smallest offset k for which c.Rotate(k) would make the loop Equal to o.
+//	ok is false when no rotation of the loop matches o.
+func (c DoubleLoop) RotationOffset(o *DoubleLoop) (k int, ok bool) {
+	if o != nil {
+		k, ok = c.rotationOffset(&o.ListHeader)
+	}
+	return
+}
+
+//	Rotates the loop so that it starts with its lexicographically least rotation under less, returning the offset rotated by.
+func (c *DoubleLoop) Canonicalize(less func(a, b interface{}) bool) (k int) {
+	if c != nil && c.length > 1 {
+		k = c.leastRotation(less)
+		c.Rotate(k)
+	}
+	return
+}
@@ -0,0 +1,100 @@
+package lists
+
+import "testing"
+
+func TestRotationOffset(t *testing.T) {
+	ConfirmOffset := func(c, o *CycList, k int) {
+		switch x, ok := c.RotationOffset(o); {
+		case !ok:						t.Fatalf("%v.RotationOffset(%v) should succeed", c, o)
+		case x != k:					t.Fatalf("%v.RotationOffset(%v) should be %v not %v", c, o, k, x)
+		case !c.EquivalentRotation(o):	t.Fatalf("%v.EquivalentRotation(%v) should be true", c, o)
+		}
+		c.Rotate(k)
+		if !c.Equal(o) {
+			t.Fatalf("Rotate(%v) should be %v but is %v", k, o, c)
+		}
+	}
+	RefuteOffset := func(c, o *CycList) {
+		switch _, ok := c.RotationOffset(o); {
+		case ok:						t.Fatalf("%v.RotationOffset(%v) should fail", c, o)
+		case c.EquivalentRotation(o):	t.Fatalf("%v.EquivalentRotation(%v) should be false", c, o)
+		}
+	}
+	ConfirmOffset(Loop(), Loop(), 0)
+	ConfirmOffset(Loop(1), Loop(1), 0)
+	ConfirmOffset(Loop(1, 2, 3), Loop(1, 2, 3), 0)
+	ConfirmOffset(Loop(1, 2, 3), Loop(2, 3, 1), 1)
+	ConfirmOffset(Loop(1, 2, 3), Loop(3, 1, 2), 2)
+	ConfirmOffset(Loop(1, 1, 2, 1, 1, 2), Loop(2, 1, 1, 2, 1, 1), 2)
+	ConfirmOffset(Loop(0, 0, 0, 1, 0, 0, 1), Loop(0, 1, 0, 0, 1, 0, 0), 2)
+	ConfirmOffset(Loop(List(1), "a"), Loop("a", List(1)), 1)
+
+	RefuteOffset(Loop(1, 2, 3), Loop(1, 3, 2))
+	RefuteOffset(Loop(1, 2, 3), Loop(1, 2))
+	RefuteOffset(Loop(1, 1, 2), Loop(1, 2, 2))
+	RefuteOffset(Loop(1), nil)
+
+	d := DLoop(1, 2, 3)
+	if k, ok := d.RotationOffset(DLoop(3, 1, 2)); !ok || k != 2 {
+		t.Fatalf("%v.RotationOffset(%v) should be 2 not %v", d, DLoop(3, 1, 2), k)
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	ConfirmCanonical := func(c, r *CycList) {
+		o := c.Clone()
+		k := c.Canonicalize(intLess)
+		switch {
+		case !c.Equal(r):									t.Fatalf("%v.Canonicalize() should be %v but is %v", o, r, c)
+		case k < 0 || k >= o.Len() && o.Len() > 0:			t.Fatalf("%v.Canonicalize() offset %v is out of range", o, k)
+		}
+		if o.Len() > 0 {
+			o.Rotate(k)
+			if !o.Equal(r) {
+				t.Fatalf("%v.Canonicalize() offset %v is incorrect", c, k)
+			}
+		}
+	}
+	ConfirmCanonical(Loop(), Loop())
+	ConfirmCanonical(Loop(1), Loop(1))
+	ConfirmCanonical(Loop(3, 1, 2), Loop(1, 2, 3))
+	ConfirmCanonical(Loop(2, 1, 2, 1, 1), Loop(1, 1, 2, 1, 2))
+	ConfirmCanonical(Loop(1, 2, 1, 2), Loop(1, 2, 1, 2))
+	ConfirmCanonical(Loop(5, 5, 5), Loop(5, 5, 5))
+
+	for n := 1; n < 8; n++ {
+		for seed := 0; seed < 40; seed++ {
+			c := Loop()
+			for i, x := 0, seed; i < n; i++ {
+				c.Append(x % 3)
+				x = x * 7 + 3
+			}
+			least := c.Clone()
+			for k := 1; k < n; k++ {
+				r := c.Clone()
+				r.Rotate(k)
+				if lexicographicallyLess(r, least) {
+					least = r
+				}
+			}
+			ConfirmCanonical(c, least)
+		}
+	}
+
+	a, b := Loop(4, 2, 9, 2), Loop(2, 4, 2, 9)
+	a.Canonicalize(intLess)
+	b.Canonicalize(intLess)
+	if !a.Equal(b) {
+		t.Fatalf("rotations should share a canonical form but %v differs from %v", a, b)
+	}
+}
+
+func lexicographicallyLess(a, b *CycList) bool {
+	for i := 0; i < a.Len(); i++ {
+		switch x, y := a.At(i).(int), b.At(i).(int); {
+		case x < y:		return true
+		case x > y:		return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,366 @@
+package lists
+
+/*
+	The set operations treat a list as a set of its distinct elements. Each returns a new list
+	of the same node type holding no duplicated elements, in the order in which they are first
+	found in the receiver followed, where appropriate, by those of the other collection.
+
+	Unique, Union, Intersection, Difference and SymmetricDifference compare elements in the
+	same way as Equal, using the Equal method of Equatable elements, and so take O(n²) time.
+	Their Hash counterparts compare elements with == using a map, which takes O(n) time but
+	panics if an element cannot be used as a map key, such as a slice.
+*/
+
+type valueSet interface {
+	has(interface{}) bool
+	add(interface{})
+}
+
+type equalitySet []interface{}
+
+func (s *equalitySet) has(v interface{}) bool {
+	for _, x := range *s {
+		if equalValues(x, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *equalitySet) add(v interface{}) {
+	*s = append(*s, v)
+}
+
+func newEqualitySet() valueSet {
+	return &equalitySet{}
+}
+
+type hashSet map[interface{}]bool
+
+func (s hashSet) has(v interface{}) bool {
+	return s[v]
+}
+
+func (s hashSet) add(v interface{}) {
+	s[v] = true
+}
+
+func newHashSet() valueSet {
+	return hashSet{}
+}
+
+//	Collects the elements of an Iterable into a set
+func collect(o Iterable, s valueSet) valueSet {
+	if o != nil {
+		o.Each(func(v interface{}) { s.add(v) })
+	}
+	return s
+}
+
+//	Appends the elements of o for which keep is true and which have not been seen before, marking them as seen
+func (l *ListHeader) appendDistinct(o Iterable, seen valueSet, keep func(interface{}) bool) {
+	if o != nil {
+		o.Each(func(v interface{}) {
+			if !seen.has(v) && keep(v) {
+				seen.add(v)
+				l.appendValue(v)
+			}
+		})
+	}
+}
+
+func keepAll(interface{}) bool {
+	return true
+}
+
+func (l ListHeader) unique(newSet func() valueSet) (r *ListHeader) {
+	r = l.newHeader()
+	r.appendDistinct(&l, newSet(), keepAll)
+	return
+}
+
+func (l ListHeader) union(o Iterable, newSet func() valueSet) (r *ListHeader) {
+	r = l.newHeader()
+	seen := newSet()
+	r.appendDistinct(&l, seen, keepAll)
+	r.appendDistinct(o, seen, keepAll)
+	return
+}
+
+func (l ListHeader) intersection(o Iterable, newSet func() valueSet) (r *ListHeader) {
+	r = l.newHeader()
+	s := collect(o, newSet())
+	r.appendDistinct(&l, newSet(), s.has)
+	return
+}
+
+func (l ListHeader) difference(o Iterable, newSet func() valueSet) (r *ListHeader) {
+	r = l.newHeader()
+	s := collect(o, newSet())
+	r.appendDistinct(&l, newSet(), func(v interface{}) bool { return !s.has(v) })
+	return
+}
+
+func (l ListHeader) symmetricDifference(o Iterable, newSet func() valueSet) (r *ListHeader) {
+	r = l.newHeader()
+	s := collect(o, newSet())
+	t := collect(&l, newSet())
+	seen := newSet()
+	r.appendDistinct(&l, seen, func(v interface{}) bool { return !s.has(v) })
+	r.appendDistinct(o, seen, func(v interface{}) bool { return !t.has(v) })
+	return
+}
+
+
+//	Returns a new list of the same node type holding the first occurrence of each distinct element
+func (l ListHeader) Unique() *ListHeader {
+	return l.unique(newEqualitySet)
+}
+
+//	Returns a new list of the same node type holding the distinct elements of the list followed by those of o which are not in the list
+func (l ListHeader) Union(o Iterable) *ListHeader {
+	return l.union(o, newEqualitySet)
+}
+
+//	Returns a new list of the same node type holding the distinct elements of the list which are also in o
+func (l ListHeader) Intersection(o Iterable) *ListHeader {
+	return l.intersection(o, newEqualitySet)
+}
+
+//	Returns a new list of the same node type holding the distinct elements of the list which are not in o
+func (l ListHeader) Difference(o Iterable) *ListHeader {
+	return l.difference(o, newEqualitySet)
+}
+
+//	Returns a new list of the same node type holding the distinct elements of the list which are not in o followed by those of o which are not in the list
+func (l ListHeader) SymmetricDifference(o Iterable) *ListHeader {
+	return l.symmetricDifference(o, newEqualitySet)
+}
+
+//	As Unique, comparing elements with ==
+func (l ListHeader) UniqueHash() *ListHeader {
+	return l.unique(newHashSet)
+}
+
+//	As Union, comparing elements with ==
+func (l ListHeader) UnionHash(o Iterable) *ListHeader {
+	return l.union(o, newHashSet)
+}
+
+//	As Intersection, comparing elements with ==
+func (l ListHeader) IntersectionHash(o Iterable) *ListHeader {
+	return l.intersection(o, newHashSet)
+}
+
+//	As Difference, comparing elements with ==
+func (l ListHeader) DifferenceHash(o Iterable) *ListHeader {
+	return l.difference(o, newHashSet)
+}
+
+//	As SymmetricDifference, comparing elements with ==
+func (l ListHeader) SymmetricDifferenceHash(o Iterable) *ListHeader {
+	return l.symmetricDifference(o, newHashSet)
+}
+
+
+func (l LinearList) Unique() *LinearList {
+	return &LinearList{ *l.ListHeader.Unique() }
+}
+
+func (l LinearList) Union(o Iterable) *LinearList {
+	return &LinearList{ *l.ListHeader.Union(o) }
+}
+
+func (l LinearList) Intersection(o Iterable) *LinearList {
+	return &LinearList{ *l.ListHeader.Intersection(o) }
+}
+
+func (l LinearList) Difference(o Iterable) *LinearList {
+	return &LinearList{ *l.ListHeader.Difference(o) }
+}
+
+func (l LinearList) SymmetricDifference(o Iterable) *LinearList {
+	return &LinearList{ *l.ListHeader.SymmetricDifference(o) }
+}
+
+func (l LinearList) UniqueHash() *LinearList {
+	return &LinearList{ *l.ListHeader.UniqueHash() }
+}
+
+func (l LinearList) UnionHash(o Iterable) *LinearList {
+	return &LinearList{ *l.ListHeader.UnionHash(o) }
+}
+
+func (l LinearList) IntersectionHash(o Iterable) *LinearList {
+	return &LinearList{ *l.ListHeader.IntersectionHash(o) }
+}
+
+func (l LinearList) DifferenceHash(o Iterable) *LinearList {
+	return &LinearList{ *l.ListHeader.DifferenceHash(o) }
+}
+
+func (l LinearList) SymmetricDifferenceHash(o Iterable) *LinearList {
+	return &LinearList{ *l.ListHeader.SymmetricDifferenceHash(o) }
+}
+
+
+func (c CycList) Unique() (r *CycList) {
+	r = &CycList{ *c.ListHeader.Unique() }
+	r.closeLoop()
+	return
+}
+
+func (c CycList) Union(o Iterable) (r *CycList) {
+	r = &CycList{ *c.ListHeader.Union(o) }
+	r.closeLoop()
+	return
+}
+
+func (c CycList) Intersection(o Iterable) (r *CycList) {
+	r = &CycList{ *c.ListHeader.Intersection(o) }
+	r.closeLoop()
+	return
+}
+
+func (c CycList) Difference(o Iterable) (r *CycList) {
+	r = &CycList{ *c.ListHeader.Difference(o) }
+	r.closeLoop()
+	return
+}
+
+func (c CycList) SymmetricDifference(o Iterable) (r *CycList) {
+	r = &CycList{ *c.ListHeader.SymmetricDifference(o) }
+	r.closeLoop()
+	return
+}
+
+func (c CycList) UniqueHash() (r *CycList) {
+	r = &CycList{ *c.ListHeader.UniqueHash() }
+	r.closeLoop()
+	return
+}
+
+func (c CycList) UnionHash(o Iterable) (r *CycList) {
+	r = &CycList{ *c.ListHeader.UnionHash(o) }
+	r.closeLoop()
+	return
+}
+
+func (c CycList) IntersectionHash(o Iterable) (r *CycList) {
+	r = &CycList{ *c.ListHeader.IntersectionHash(o) }
+	r.closeLoop()
+	return
+}
+
+func (c CycList) DifferenceHash(o Iterable) (r *CycList) {
+	r = &CycList{ *c.ListHeader.DifferenceHash(o) }
+	r.closeLoop()
+	return
+}
+
+func (c CycList) SymmetricDifferenceHash(o Iterable) (r *CycList) {
+	r = &CycList{ *c.ListHeader.SymmetricDifferenceHash(o) }
+	r.closeLoop()
+	return
+}
+
+func (l DoubleList) Unique() *DoubleList {
+	return &DoubleList{ *l.ListHeader.Unique() }
+}
+
+func (l DoubleList) Union(o Iterable) *DoubleList {
+	return &DoubleList{ *l.ListHeader.Union(o) }
+}
+
+func (l DoubleList) Intersection(o Iterable) *DoubleList {
+	return &DoubleList{ *l.ListHeader.Intersection(o) }
+}
+
+func (l DoubleList) Difference(o Iterable) *DoubleList {
+	return &DoubleList{ *l.ListHeader.Difference(o) }
+}
+
+func (l DoubleList) SymmetricDifference(o Iterable) *DoubleList {
+	return &DoubleList{ *l.ListHeader.SymmetricDifference(o) }
+}
+
+func (l DoubleList) UniqueHash() *DoubleList {
+	return &DoubleList{ *l.ListHeader.UniqueHash() }
+}
+
+func (l DoubleList) UnionHash(o Iterable) *DoubleList {
+	return &DoubleList{ *l.ListHeader.UnionHash(o) }
+}
+
+func (l DoubleList) IntersectionHash(o Iterable) *DoubleList {
+	return &DoubleList{ *l.ListHeader.IntersectionHash(o) }
+}
+
+func (l DoubleList) DifferenceHash(o Iterable) *DoubleList {
+	return &DoubleList{ *l.ListHeader.DifferenceHash(o) }
+}
+
+func (l DoubleList) SymmetricDifferenceHash(o Iterable) *DoubleList {
+	return &DoubleList{ *l.ListHeader.SymmetricDifferenceHash(o) }
+}
+
+
+func (c DoubleLoop) Unique() (r *DoubleLoop) {
+	r = &DoubleLoop{ *c.ListHeader.Unique() }
+	r.closeLoop()
+	return
+}
+
+func (c DoubleLoop) Union(o Iterable) (r *DoubleLoop) {
+	r = &DoubleLoop{ *c.ListHeader.Union(o) }
+	r.closeLoop()
+	return
+}
+
+func (c DoubleLoop) Intersection(o Iterable) (r *DoubleLoop) {
+	r = &DoubleLoop{ *c.ListHeader.Intersection(o) }
+	r.closeLoop()
+	return
+}
+
+func (c DoubleLoop) Difference(o Iterable) (r *DoubleLoop) {
+	r = &DoubleLoop{ *c.ListHeader.Difference(o) }
+	r.closeLoop()
+	return
+}
+
+func (c DoubleLoop) SymmetricDifference(o Iterable) (r *DoubleLoop) {
+	r = &DoubleLoop{ *c.ListHeader.SymmetricDifference(o) }
+	r.closeLoop()
+	return
+}
+
+func (c DoubleLoop) UniqueHash() (r *DoubleLoop) {
+	r = &DoubleLoop{ *c.ListHeader.UniqueHash() }
+	r.closeLoop()
+	return
+}
+
+func (c DoubleLoop) UnionHash(o Iterable) (r *DoubleLoop) {
+	r = &DoubleLoop{ *c.ListHeader.UnionHash(o) }
+	r.closeLoop()
+	return
+}
+
+func (c DoubleLoop) IntersectionHash(o Iterable) (r *DoubleLoop) {
+	r = &DoubleLoop{ *c.ListHeader.IntersectionHash(o) }
+	r.closeLoop()
+	return
+}
+
+func (c DoubleLoop) DifferenceHash(o Iterable) (r *DoubleLoop) {
+	r = &DoubleLoop{ *c.ListHeader.DifferenceHash(o) }
+	r.closeLoop()
+	return
+}
+
+func (c DoubleLoop) SymmetricDifferenceHash(o Iterable) (r *DoubleLoop) {
+	r = &DoubleLoop{ *c.ListHeader.SymmetricDifferenceHash(o) }
+	r.closeLoop()
+	return
+}
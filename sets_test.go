@@ -0,0 +1,119 @@
+package lists
+
+import "reflect"
+import "testing"
+
+func TestUnique(t *testing.T) {
+	ConfirmUnique := func(l, r *LinearList) {
+		if x := l.Unique(); !x.Equal(r) {
+			t.Fatalf("%v.Unique() should be %v but is %v", l, r, x)
+		}
+		if x := l.UniqueHash(); !x.Equal(r) {
+			t.Fatalf("%v.UniqueHash() should be %v but is %v", l, r, x)
+		}
+	}
+	ConfirmUnique(List(), List())
+	ConfirmUnique(List(0), List(0))
+	ConfirmUnique(List(0, 0, 0), List(0))
+	ConfirmUnique(List(2, 1, 2, 0, 1), List(2, 1, 0))
+	ConfirmUnique(List("a", 1, "a", 1.0), List("a", 1, 1.0))
+
+	l := List([]int{ 0 }, List(1, 2), []int{ 0 }, List(1, 2))
+	if x := l.Unique(); !x.Equal(List([]int{ 0 }, List(1, 2))) {
+		t.Fatalf("%v.Unique() should compare uncomparable and nested values by content not %v", l, x)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("UniqueHash() should panic for uncomparable values")
+		}
+	}()
+	l.UniqueHash()
+}
+
+func TestSetOperations(t *testing.T) {
+	ConfirmSet := func(name string, l, r *LinearList, f, h func(*LinearList) *LinearList) {
+		if x := f(l); !x.Equal(r) {
+			t.Fatalf("%v.%v() should be %v but is %v", l, name, r, x)
+		}
+		if x := h(l); !x.Equal(r) {
+			t.Fatalf("%v.%vHash() should be %v but is %v", l, name, r, x)
+		}
+	}
+	a, b := List(3, 1, 2, 1), List(2, 4, 3, 4)
+	ConfirmSet("Union", a, List(3, 1, 2, 4),
+		func(l *LinearList) *LinearList { return l.Union(b) },
+		func(l *LinearList) *LinearList { return l.UnionHash(b) })
+	ConfirmSet("Intersection", a, List(3, 2),
+		func(l *LinearList) *LinearList { return l.Intersection(b) },
+		func(l *LinearList) *LinearList { return l.IntersectionHash(b) })
+	ConfirmSet("Difference", a, List(1),
+		func(l *LinearList) *LinearList { return l.Difference(b) },
+		func(l *LinearList) *LinearList { return l.DifferenceHash(b) })
+	ConfirmSet("SymmetricDifference", a, List(1, 4),
+		func(l *LinearList) *LinearList { return l.SymmetricDifference(b) },
+		func(l *LinearList) *LinearList { return l.SymmetricDifferenceHash(b) })
+
+	ConfirmSet("Union", List(), List(1),
+		func(l *LinearList) *LinearList { return l.Union(List(1, 1)) },
+		func(l *LinearList) *LinearList { return l.UnionHash(List(1, 1)) })
+	ConfirmSet("Intersection", a, List(),
+		func(l *LinearList) *LinearList { return l.Intersection(nil) },
+		func(l *LinearList) *LinearList { return l.IntersectionHash(nil) })
+	ConfirmSet("Difference", a, List(3, 1, 2),
+		func(l *LinearList) *LinearList { return l.Difference(Loop(4)) },
+		func(l *LinearList) *LinearList { return l.DifferenceHash(Loop(4)) })
+}
+
+func TestSetNodeType(t *testing.T) {
+	l := NewLinearList(&DoubleNode{})
+	l.Concatenate([]interface{}{ 0, 1, 0 })
+	if x := l.Union(List(2)); reflect.TypeOf(x.Start()) != reflect.TypeOf(&DoubleNode{}) || !x.Equal(List(0, 1, 2)) {
+		t.Fatalf("Union() should keep the node type of %v", l)
+	}
+
+	c := Loop(0, 1, 0, 2).Difference(List(2))
+	switch {
+	case !c.Equal(Loop(0, 1)):		t.Fatalf("Difference() should be %v but is %v", Loop(0, 1), c)
+	case c.At(2) != 0:				t.Fatalf("Difference() should return a closed loop")
+	}
+}
+
+func TestDoubleSetOperations(t *testing.T) {
+	l := DList(3, 1, 3, 2)
+	ConfirmSet := func(name string, x, r *DoubleList) {
+		if !x.Equal(r) {
+			t.Fatalf("%v should be %v but is %v", name, r, x)
+		}
+		confirmDoubleLinks(t, x.ListHeader)
+	}
+	ConfirmSet("Unique()", l.Unique(), DList(3, 1, 2))
+	ConfirmSet("Union()", l.Union(List(4, 1)), DList(3, 1, 2, 4))
+	ConfirmSet("Intersection()", l.Intersection(List(2, 3)), DList(3, 2))
+	ConfirmSet("Difference()", l.Difference(List(3)), DList(1, 2))
+	ConfirmSet("SymmetricDifference()", l.SymmetricDifference(List(2, 5)), DList(3, 1, 5))
+	ConfirmSet("UniqueHash()", l.UniqueHash(), DList(3, 1, 2))
+	ConfirmSet("UnionHash()", l.UnionHash(List(4, 1)), DList(3, 1, 2, 4))
+	ConfirmSet("IntersectionHash()", l.IntersectionHash(List(2, 3)), DList(3, 2))
+	ConfirmSet("DifferenceHash()", l.DifferenceHash(List(3)), DList(1, 2))
+	ConfirmSet("SymmetricDifferenceHash()", l.SymmetricDifferenceHash(List(2, 5)), DList(3, 1, 5))
+
+	c := DLoop(0, 1, 0, 2)
+	ConfirmLoop := func(name string, x, r *DoubleLoop) {
+		switch {
+		case !x.Equal(r):				t.Fatalf("%v should be %v but is %v", name, r, x)
+		case x.Validate() != nil:		t.Fatalf("%v should return a closed loop: %v", name, x.Validate())
+		}
+		confirmDoubleLinks(t, x.ListHeader)
+	}
+	ConfirmLoop("Unique()", c.Unique(), DLoop(0, 1, 2))
+	ConfirmLoop("Union()", c.Union(List(3)), DLoop(0, 1, 2, 3))
+	ConfirmLoop("Intersection()", c.Intersection(List(2, 0)), DLoop(0, 2))
+	ConfirmLoop("Difference()", c.Difference(List(2)), DLoop(0, 1))
+	ConfirmLoop("SymmetricDifference()", c.SymmetricDifference(List(1, 3)), DLoop(0, 2, 3))
+	ConfirmLoop("UniqueHash()", c.UniqueHash(), DLoop(0, 1, 2))
+	ConfirmLoop("UnionHash()", c.UnionHash(List(3)), DLoop(0, 1, 2, 3))
+	ConfirmLoop("IntersectionHash()", c.IntersectionHash(List(2, 0)), DLoop(0, 2))
+	ConfirmLoop("DifferenceHash()", c.DifferenceHash(List(2)), DLoop(0, 1))
+	ConfirmLoop("SymmetricDifferenceHash()", c.SymmetricDifferenceHash(List(1, 3)), DLoop(0, 2, 3))
+}
@@ -0,0 +1,90 @@
+package lists
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"github.com/feyeleanor/chain"
+)
+
+/*
+	Lists may contain other lists, including themselves, so both printing and comparison
+	keep track of the lists they have already encountered.
+
+	A list is identified by its start node and length, so copies of a ListHeader describe
+	the same list as the original.
+*/
+
+type listKey struct {
+	start		chain.Node
+	length		int
+}
+
+func (l *ListHeader) key() listKey {
+	return listKey{ l.start, l.length }
+}
+
+func (l *ListHeader) header() *ListHeader {
+	return l
+}
+
+//	Returns the ListHeader underlying a pointer to one of the list types, or nil for any other value
+func headerOf(v interface{}) (h *ListHeader) {
+	if x, ok := v.(interface{ header() *ListHeader }); ok {
+		if r := reflect.ValueOf(v); r.Kind() != reflect.Ptr || !r.IsNil() {
+			h = x.header()
+		}
+	}
+	return
+}
+
+
+type printer struct {
+	seen		map[listKey]int
+	labels		map[listKey]int
+}
+
+func newPrinter() *printer {
+	return &printer{ seen: make(map[listKey]int), labels: make(map[listKey]int) }
+}
+
+//	Counts how often each non-empty list is reachable
+func (p *printer) scan(l *ListHeader) {
+	k := l.key()
+	if p.seen[k]++; p.seen[k] == 1 {
+		l.eachNode(func(i int, n chain.Node) {
+			if h := headerOf(n.Content()); h != nil && h.length > 0 {
+				p.scan(h)
+			}
+		})
+	}
+}
+
+func (p *printer) format(l *ListHeader) string {
+	k := l.key()
+	label := ""
+	if p.seen[k] > 1 {
+		if n, ok := p.labels[k]; ok {
+			return fmt.Sprintf("#%v#", n)
+		}
+		p.labels[k] = len(p.labels) + 1
+		label = fmt.Sprintf("#%v=", p.labels[k])
+	}
+
+	terms := []string{}
+	l.Each(func(term interface{}) {
+		switch h := headerOf(term); {
+		case h == nil:			terms = append(terms, formatTerm(term))
+		case h.length == 0:		if isLoop(term) {
+									terms = append(terms, "(...)")
+								} else {
+									terms = append(terms, "()")
+								}
+		default:				terms = append(terms, p.format(h))
+		}
+	})
+	if l.length > 0 && l.start == l.next(l.end) {
+		terms = append(terms, "...")
+	}
+	return label + "(" + strings.Join(terms, " ") + ")"
+}
@@ -0,0 +1,57 @@
+package lists
+
+import "testing"
+
+func TestSelfReferentialString(t *testing.T) {
+	ConfirmFormat := func(l interface{ String() string }, x string) {
+		if s := l.String(); s != x {
+			t.Fatalf("'%v' erroneously serialised as '%v'", x, s)
+		}
+	}
+	l := List(Symbol("a"))
+	l.Append(l)
+	ConfirmFormat(l, "#1=(a #1#)")
+
+	c := Loop(1)
+	c.Append(c)
+	ConfirmFormat(c, "#1=(1 #1# ...)")
+
+	s := List(1)
+	ConfirmFormat(List(s, s), "(#1=(1) #1#)")
+	ConfirmFormat(List(s, List(s), 2), "(#1=(1) (#1#) 2)")
+
+	x := List(0)
+	y := List(1, x)
+	x.Append(y)
+	ConfirmFormat(List(x, y), "(#1=(0 #2=(1 #1#)) #2#)")
+
+	ConfirmFormat(List(List(), List()), "(() ())")
+}
+
+func TestSelfReferentialEqual(t *testing.T) {
+	l := List(Symbol("a"))
+	l.Append(l)
+	o := List(Symbol("a"))
+	o.Append(o)
+	if !l.Equal(o) {
+		t.Fatalf("%v should equal %v", l, o)
+	}
+
+	x := List(Symbol("b"))
+	x.Append(x)
+	if l.Equal(x) {
+		t.Fatalf("%v should not equal %v", l, x)
+	}
+
+	c := Loop(1)
+	c.Append(c)
+	d := Loop(1)
+	d.Append(d)
+	if !c.Equal(d) {
+		t.Fatalf("%v should equal %v", c, d)
+	}
+
+	if List(List(1)).Equal(List(Loop(1))) {
+		t.Fatalf("%v should not equal %v", List(List(1)), List(Loop(1)))
+	}
+}
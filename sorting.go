@@ -0,0 +1,136 @@
+package lists
+
+import "github.com/feyeleanor/chain"
+
+//	Sorts the n nodes following and including start using a merge sort, relinking the existing nodes.
+//	Returns the first and last nodes of the sorted chain, leaving the last node's link unspecified.
+func mergeSort(start chain.Node, n int, less func(a, b interface{}) bool) (first, last chain.Node) {
+	if n < 2 {
+		return start, start
+	}
+	half := n / 2
+	middle := start.MoveTo(half)
+	a, _ := mergeSort(start, half, less)
+	b, _ := mergeSort(middle, n - half, less)
+	return mergeNodes(a, half, b, n - half, less)
+}
+
+//	Merges two sorted chains of nodes, preferring nodes from the first chain when elements are equal.
+func mergeNodes(a chain.Node, na int, b chain.Node, nb int, less func(a, b interface{}) bool) (first, last chain.Node) {
+	take := func(n chain.Node) {
+		if last == nil {
+			first = n
+		} else {
+			last.Link(chain.NEXT_NODE, n)
+		}
+		last = n
+	}
+	for na > 0 && nb > 0 {
+		if less(b.Content(), a.Content()) {
+			n := b
+			b = chain.Next(b)
+			nb--
+			take(n)
+		} else {
+			n := a
+			a = chain.Next(a)
+			na--
+			take(n)
+		}
+	}
+	for ; na > 0; na-- {
+		n := a
+		a = chain.Next(a)
+		take(n)
+	}
+	for ; nb > 0; nb-- {
+		n := b
+		b = chain.Next(b)
+		take(n)
+	}
+	return
+}
+
+//	Sorts the elements of the list into the order defined by less.
+//	The existing nodes are relinked in place, so no nodes are allocated and node identity is preserved.
+//	As the list is merge sorted the sort is also stable.
+func (l *ListHeader) Sort(less func(a, b interface{}) bool) {
+	l.SortStable(less)
+}
+
+//	Sorts the elements of the list into the order defined by less, keeping equal elements in their original order.
+func (l *ListHeader) SortStable(less func(a, b interface{}) bool) {
+	if l != nil && l.length > 1 {
+		l.straighten()
+		closed := chain.Next(l.end) == l.start
+		l.start, l.end = mergeSort(l.start, l.length, less)
+		if closed {
+			l.end.Link(chain.NEXT_NODE, l.start)
+		} else {
+			l.end.Link(chain.NEXT_NODE, nil)
+			l.start.Link(chain.PREVIOUS_NODE, nil)
+		}
+		l.invalidate(0)
+	}
+}
+
+//	Determines whether the elements of the list are in the order defined by less.
+func (l ListHeader) IsSorted(less func(a, b interface{}) bool) bool {
+	var previous interface{}
+	for i, v := range l.All() {
+		if i > 0 && less(v, previous) {
+			return false
+		}
+		previous = v
+	}
+	return true
+}
+
+//	Inserts a value into a sorted list after any elements which do not sort after it, returning its position.
+func (l *ListHeader) SortedInsert(v interface{}, less func(a, b interface{}) bool) int {
+	return l.sortedInsert(v, less, l.length > 0 && l.next(l.end) == l.start)
+}
+
+func (l *ListHeader) sortedInsert(v interface{}, less func(a, b interface{}) bool, closed bool) (i int) {
+	var previous chain.Node
+
+	l.straighten()
+	n := l.NewListNode(v)
+	for x := l.start; i < l.length && !less(v, x.Content()); i++ {
+		previous = x
+		x = chain.Next(x)
+	}
+
+	switch {
+	case l.length == 0:		l.start = n
+							l.end = n
+							if closed {
+								n.Link(chain.NEXT_NODE, n)
+							}
+
+	case previous == nil:	n.Link(chain.NEXT_NODE, l.start)
+							if closed {
+								l.end.Link(chain.NEXT_NODE, n)
+							}
+							l.start = n
+
+	default:				n.Link(chain.NEXT_NODE, chain.Next(previous))
+							previous.Link(chain.NEXT_NODE, n)
+							if previous == l.end {
+								l.end = n
+							}
+	}
+	l.length++
+	l.invalidate(i)
+	return
+}
+
+//	Inserts a value into a sorted loop after any elements which do not sort after it, returning its position.
+func (c *CycList) SortedInsert(v interface{}, less func(a, b interface{}) bool) int {
+	return c.sortedInsert(v, less, true)
+}
+
+//	Inserts a value into a sorted loop after any elements which do not sort after it, returning its position.
+func (c *DoubleLoop) SortedInsert(v interface{}, less func(a, b interface{}) bool) int {
+	return c.sortedInsert(v, less, true)
+}
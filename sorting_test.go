@@ -0,0 +1,135 @@
+package lists
+
+import "github.com/feyeleanor/chain"
+import "testing"
+
+func intLess(a, b interface{}) bool {
+	return a.(int) < b.(int)
+}
+
+func TestLinearListSort(t *testing.T) {
+	ConfirmSort := func(l, r *LinearList) {
+		nodes := map[chain.Node]bool{}
+		l.eachNode(func(i int, n chain.Node) {
+			nodes[n] = true
+		})
+		l.Sort(intLess)
+		switch {
+		case !l.Equal(r):					t.Fatalf("Sort() should be %v but is %v", r, l)
+		case l.End() != l.findNode(l.Len() - 1):	t.Fatalf("Sort() end of %v is incorrect", l)
+		case l.Len() > 0 && chain.Next(l.End()) != nil:		t.Fatalf("Sort() should terminate %v", l)
+		}
+		l.eachNode(func(i int, n chain.Node) {
+			if !nodes[n] {
+				t.Fatalf("Sort() should reuse the nodes of %v", l)
+			}
+		})
+	}
+	ConfirmSort(List(), List())
+	ConfirmSort(List(1), List(1))
+	ConfirmSort(List(2, 1), List(1, 2))
+	ConfirmSort(List(3, 1, 2), List(1, 2, 3))
+	ConfirmSort(List(5, 3, 9, 1, 1, 8, 0, 7), List(0, 1, 1, 3, 5, 7, 8, 9))
+	ConfirmSort(List(9, 8, 7, 6, 5, 4, 3, 2, 1, 0), List(0, 1, 2, 3, 4, 5, 6, 7, 8, 9))
+}
+
+func TestLinearListSortStable(t *testing.T) {
+	type pair struct {
+		key		int
+		value	string
+	}
+	l := List(pair{ 2, "a" }, pair{ 1, "b" }, pair{ 2, "c" }, pair{ 1, "d" }, pair{ 0, "e" })
+	l.SortStable(func(a, b interface{}) bool {
+		return a.(pair).key < b.(pair).key
+	})
+	r := List(pair{ 0, "e" }, pair{ 1, "b" }, pair{ 1, "d" }, pair{ 2, "a" }, pair{ 2, "c" })
+	if !l.Equal(r) {
+		t.Fatalf("SortStable() should be %v but is %v", r, l)
+	}
+}
+
+func TestCycListSort(t *testing.T) {
+	ConfirmSort := func(c, r *CycList) {
+		c.Sort(intLess)
+		switch {
+		case !c.Equal(r):								t.Fatalf("Sort() should be %v but is %v", r, c)
+		case c.Len() > 0 && chain.Next(c.end) != c.start:	t.Fatalf("Sort() should keep %v closed", c)
+		}
+	}
+	ConfirmSort(Loop(), Loop())
+	ConfirmSort(Loop(1), Loop(1))
+	ConfirmSort(Loop(2, 1), Loop(1, 2))
+	ConfirmSort(Loop(4, 2, 3, 1, 0), Loop(0, 1, 2, 3, 4))
+}
+
+func TestDoubleListSort(t *testing.T) {
+	l := DList(4, 2, 3, 1, 0)
+	l.Sort(intLess)
+	if r := DList(0, 1, 2, 3, 4); !l.Equal(r) {
+		t.Fatalf("Sort() should be %v but is %v", r, l)
+	}
+	confirmDoubleLinks(t, l.ListHeader)
+
+	c := DLoop(4, 2, 3, 1, 0)
+	c.Sort(intLess)
+	if r := DLoop(0, 1, 2, 3, 4); !c.Equal(r) {
+		t.Fatalf("Sort() should be %v but is %v", r, c)
+	}
+	confirmDoubleLinks(t, c.ListHeader)
+}
+
+func TestIsSorted(t *testing.T) {
+	ConfirmSorted := func(l *LinearList) {
+		if !l.IsSorted(intLess) {
+			t.Fatalf("%v should be sorted", l)
+		}
+	}
+	RefuteSorted := func(l *LinearList) {
+		if l.IsSorted(intLess) {
+			t.Fatalf("%v should not be sorted", l)
+		}
+	}
+	ConfirmSorted(List())
+	ConfirmSorted(List(1))
+	ConfirmSorted(List(1, 1, 2))
+	RefuteSorted(List(2, 1))
+	RefuteSorted(List(1, 3, 2))
+}
+
+func TestSortedInsert(t *testing.T) {
+	ConfirmSortedInsert := func(l *LinearList, v interface{}, i int, r *LinearList) {
+		switch x := l.SortedInsert(v, intLess); {
+		case x != i:							t.Fatalf("SortedInsert(%v) should be at %v not %v", v, i, x)
+		case !l.Equal(r):						t.Fatalf("SortedInsert(%v) should be %v but is %v", v, r, l)
+		case l.End() != l.findNode(l.Len() - 1):	t.Fatalf("SortedInsert(%v) end of %v is incorrect", v, l)
+		}
+	}
+	ConfirmSortedInsert(List(), 1, 0, List(1))
+	ConfirmSortedInsert(List(1, 3), 0, 0, List(0, 1, 3))
+	ConfirmSortedInsert(List(1, 3), 2, 1, List(1, 2, 3))
+	ConfirmSortedInsert(List(1, 3), 3, 2, List(1, 3, 3))
+	ConfirmSortedInsert(List(1, 3), 4, 2, List(1, 3, 4))
+
+	ConfirmLoopInsert := func(c *CycList, v interface{}, r *CycList) {
+		c.SortedInsert(v, intLess)
+		switch {
+		case !c.Equal(r):					t.Fatalf("SortedInsert(%v) should be %v but is %v", v, r, c)
+		case chain.Next(c.end) != c.start:	t.Fatalf("SortedInsert(%v) should keep %v closed", v, c)
+		}
+	}
+	ConfirmLoopInsert(Loop(), 1, Loop(1))
+	ConfirmLoopInsert(Loop(1, 3), 0, Loop(0, 1, 3))
+	ConfirmLoopInsert(Loop(1, 3), 2, Loop(1, 2, 3))
+	ConfirmLoopInsert(Loop(1, 3), 4, Loop(1, 3, 4))
+}
+
+func TestTypedListSort(t *testing.T) {
+	l := ListOf("pear", "apple", "fig")
+	l.Sort(func(a, b string) bool { return a < b })
+	if r := ListOf("apple", "fig", "pear"); !l.Equal(r) {
+		t.Fatalf("Sort() should be %v but is %v", r, l)
+	}
+	if l.SortedInsert("kiwi", func(a, b string) bool { return a < b }) != 2 {
+		t.Fatalf("SortedInsert() should insert kiwi at 2 in %v", l)
+	}
+}
@@ -0,0 +1,1214 @@
+package lists
+
+import "iter"
+import "sync"
+import "github.com/feyeleanor/chain"
+
+/*
+	A SyncList or SyncCycList guards a list with a sync.RWMutex so that it can be shared by
+	several goroutines. Each method holds the lock for the duration of the corresponding list
+	operation, so each is atomic with respect to the others.
+
+	A PositionCache is updated by every lookup made through it, so lookups made under the read
+	lock walk the list without its cache and leave the list untouched. The cache still serves
+	the positional operations made under the write lock.
+
+	Functions passed to Each and the functional methods are called whilst the lock is held,
+	so they must not use the same SyncList. Where another SyncList, SyncCycList or Snapshot is
+	passed as an argument its contents are copied before the lock is taken, so that lists may
+	be combined with one another or with themselves without deadlock.
+
+	Snapshot returns an immutable copy of the list which can be read without holding the lock.
+	It copies the list's structure but not its elements, so nested lists remain shared. For the
+	same reason the iterators, Cycle and Slice work on copies taken under the lock, and Cursor
+	calls a function with a Cursor whilst holding the write lock rather than returning it.
+*/
+
+type syncHeader struct {
+	mutex		sync.RWMutex
+	list		*ListHeader
+}
+
+//	Returns a copy of the list header which finds positions without the PositionCache, so that it may be read under the read lock
+func (s *syncHeader) uncached() (l ListHeader) {
+	l = *s.list
+	l.cache = nil
+	return
+}
+
+//	Replaces a synchronised list or Snapshot with a private copy of its contents
+func unshared(o interface{}) interface{} {
+	switch x := o.(type) {
+	case *SyncList:			o = x.Snapshot().List()
+	case *SyncCycList:		o = x.Snapshot().Loop()
+	case *Snapshot:			if x.cyclic {
+								o = x.Loop()
+							} else {
+								o = x.List()
+							}
+	}
+	return o
+}
+
+func unsharedIterable(o Iterable) Iterable {
+	if o != nil {
+		o = unshared(o).(Iterable)
+	}
+	return o
+}
+
+func (s *syncHeader) Len() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.Len()
+}
+
+func (s *syncHeader) String() string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.String()
+}
+
+func (s *syncHeader) UsePositionCache(c PositionCache) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.list.UsePositionCache(c)
+}
+
+func (s *syncHeader) UseNumberMode(m NumberMode) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.list.UseNumberMode(m)
+}
+
+func (s *syncHeader) PositionStats() CacheStats {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.PositionStats()
+}
+
+func (s *syncHeader) Head() interface{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.Head()
+}
+
+func (s *syncHeader) PeekFront() (interface{}, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.PeekFront()
+}
+
+func (s *syncHeader) PeekBack() (interface{}, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.PeekBack()
+}
+
+//	Returns the first node of the list. The node remains part of the list, so it should only be followed whilst no
+//	other goroutine is changing the list.
+func (s *syncHeader) Start() chain.Node {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.Start()
+}
+
+//	Returns the last node of the list, which is subject to the same caveat as Start
+func (s *syncHeader) End() chain.Node {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.End()
+}
+
+func (s *syncHeader) EnforceBounds(start, end *int) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.EnforceBounds(start, end)
+}
+
+//	Returns a new node of the type used by the list, which is not linked into the list
+func (s *syncHeader) NewListNode(v interface{}) chain.Node {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.NewListNode(v)
+}
+
+func (s *syncHeader) CheckBounds(start, end int) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.CheckBounds(start, end)
+}
+
+func (s *syncHeader) Each(f func(interface{})) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	s.list.Each(f)
+}
+
+func (s *syncHeader) Compact() []interface{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.Compact()
+}
+
+func (s *syncHeader) IsSorted(less func(a, b interface{}) bool) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.IsSorted(less)
+}
+
+func (s *syncHeader) Reduce(f func(memo, v interface{}) interface{}) interface{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.Reduce(f)
+}
+
+func (s *syncHeader) FoldLeft(seed interface{}, f func(memo, v interface{}) interface{}) interface{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.FoldLeft(seed, f)
+}
+
+func (s *syncHeader) FoldRight(seed interface{}, f func(v, memo interface{}) interface{}) interface{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.FoldRight(seed, f)
+}
+
+func (s *syncHeader) Any(f func(interface{}) bool) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.Any(f)
+}
+
+func (s *syncHeader) Every(f func(interface{}) bool) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.Every(f)
+}
+
+func (s *syncHeader) Count(f func(interface{}) bool) int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.Count(f)
+}
+
+func (s *syncHeader) Find(f func(interface{}) bool) (interface{}, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.Find(f)
+}
+
+func (s *syncHeader) FindIndex(f func(interface{}) bool) int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.FindIndex(f)
+}
+
+func (s *syncHeader) MapInPlace(f func(interface{}) interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.list.MapInPlace(f)
+}
+
+func (s *syncHeader) FilterInPlace(f func(interface{}) bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.list.FilterInPlace(f)
+}
+
+func (s *syncHeader) RejectInPlace(f func(interface{}) bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.list.RejectInPlace(f)
+}
+
+func (s *syncHeader) Sort(less func(a, b interface{}) bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.list.Sort(less)
+}
+
+func (s *syncHeader) SortStable(less func(a, b interface{}) bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.list.SortStable(less)
+}
+
+func (s *syncHeader) Flatten() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.list.Flatten()
+}
+
+//	Returns an iterator over the index and value of each element, working on a copy of the list taken when All is
+//	called so that the lock is not held during iteration
+func (s *syncHeader) All() iter.Seq2[int, interface{}] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.Clone().All()
+}
+
+//	Returns an iterator over the value of each element, working on a copy of the list as All does
+func (s *syncHeader) Values() iter.Seq[interface{}] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.Clone().Values()
+}
+
+//	Returns an iterator over the index and value of each element from the end of the list, working on a copy of the
+//	list taken when Backward is called so that the lock is not held during iteration
+func (s *syncHeader) Backward() iter.Seq2[int, interface{}] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.list.Clone().Backward()
+}
+
+//	Returns a View of a copy of the elements in the inclusive range from to to, clamped as for Slice on a list.
+//	As the View is of a copy it can be read without the lock, and setting its elements does not alter the list.
+func (s *syncHeader) Slice(from, to int) *View {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	l := s.list.newHeader()
+	if s.list.EnforceBounds(&from, &to) {
+		h := s.uncached()
+		for n := h.findNode(from); from <= to; from++ {
+			l.appendValue(n.Content())
+			n = chain.Next(n)
+		}
+	}
+	return l.Slice(0, l.length - 1)
+}
+
+
+//	A Snapshot is an immutable copy of a list taken from a SyncList or SyncCycList
+type Snapshot struct {
+	list		ListHeader
+	cyclic		bool
+}
+
+func newSnapshot(l *ListHeader, cyclic bool) (r *Snapshot) {
+	r = &Snapshot{ list: *l.Clone(), cyclic: cyclic }
+	r.list.UsePositionCache(nil)
+	if cyclic {
+		r.list.closeLoop()
+	}
+	return
+}
+
+func (s *Snapshot) Len() int {
+	return s.list.Len()
+}
+
+//	Returns the value stored at the given offset, which wraps around when the Snapshot was taken of a loop
+func (s *Snapshot) At(i int) interface{} {
+	if s.cyclic {
+		return CycList{ s.list }.At(i)
+	}
+	return s.list.At(i)
+}
+
+func (s *Snapshot) AtE(i int) (interface{}, error) {
+	if s.cyclic {
+		return CycList{ s.list }.AtE(i)
+	}
+	return s.list.AtE(i)
+}
+
+func (s *Snapshot) Each(f func(interface{})) {
+	s.list.Each(f)
+}
+
+func (s *Snapshot) All() iter.Seq2[int, interface{}] {
+	return s.list.All()
+}
+
+func (s *Snapshot) Values() iter.Seq[interface{}] {
+	return s.list.Values()
+}
+
+func (s *Snapshot) Backward() iter.Seq2[int, interface{}] {
+	return s.list.Backward()
+}
+
+//	Determines whether the Snapshot holds the same elements as a list of the kind from which it was taken
+func (s *Snapshot) Equal(o interface{}) bool {
+	if s.cyclic {
+		return CycList{ s.list }.Equal(unshared(o))
+	}
+	return LinearList{ s.list }.Equal(unshared(o))
+}
+
+func (s *Snapshot) String() string {
+	if s.cyclic {
+		return CycList{ s.list }.String()
+	}
+	return s.list.String()
+}
+
+//	Returns a new LinearList holding the elements of the Snapshot
+func (s *Snapshot) List() *LinearList {
+	return &LinearList{ *s.list.Clone() }
+}
+
+//	Returns a new CycList holding the elements of the Snapshot
+func (s *Snapshot) Loop() (r *CycList) {
+	r = &CycList{ *s.list.Clone() }
+	r.closeLoop()
+	return
+}
+
+
+//	A SyncList is a LinearList which may be shared between goroutines
+type SyncList struct {
+	syncHeader
+	linear		*LinearList
+}
+
+//	Wraps a LinearList for use by several goroutines. The list should not be used directly once wrapped.
+func NewSyncList(l *LinearList) (s *SyncList) {
+	if l == nil {
+		l = List()
+	}
+	s = &SyncList{ linear: l }
+	s.list = &l.ListHeader
+	return
+}
+
+//	Returns an immutable copy of the list which can be read without holding the lock
+func (s *SyncList) Snapshot() *Snapshot {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return newSnapshot(s.list, false)
+}
+
+func (s *SyncList) At(i int) interface{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return LinearList{ s.uncached() }.At(i)
+}
+
+func (s *SyncList) AtE(i int) (interface{}, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return LinearList{ s.uncached() }.AtE(i)
+}
+
+func (s *SyncList) Set(i int, v interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.linear.Set(i, v)
+}
+
+func (s *SyncList) SetE(i int, v interface{}) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.linear.SetE(i, v)
+}
+
+func (s *SyncList) Clear(i int) {
+	s.Set(i, nil)
+}
+
+//	Replaces the element at i with the result of applying f to it, reporting whether there is such an element.
+//	No other goroutine can use the list between f reading the element and its result being stored.
+func (s *SyncList) Update(i int, f func(interface{}) interface{}) (ok bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if n := s.linear.findNode(i); n != nil {
+		n.Set(chain.CURRENT_NODE, f(n.Content()))
+		ok = true
+	}
+	return
+}
+
+//	Sets the element at i to v provided that it currently holds a value equal to old, reporting whether it did so.
+//	Values are compared as they are by Equal.
+func (s *SyncList) CompareAndSet(i int, old, v interface{}) (ok bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if n := s.linear.findNode(i); n != nil && equalValues(n.Content(), old) {
+		n.Set(chain.CURRENT_NODE, v)
+		ok = true
+	}
+	return
+}
+
+//	Calls f with a Cursor positioned at the given element whilst holding the write lock, so that a sequence of edits
+//	made through the Cursor is atomic. Reports whether there is such a position. The Cursor must not be kept after f returns.
+func (s *SyncList) Cursor(i int, f func(*Cursor)) (ok bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if c := s.linear.Cursor(i); c != nil {
+		f(c)
+		ok = true
+	}
+	return
+}
+
+func (s *SyncList) Tail() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.linear.Tail()
+}
+
+func (s *SyncList) Expand(i, n int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.linear.Expand(i, n)
+}
+
+func (s *SyncList) PushFront(v interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.linear.PushFront(v)
+}
+
+func (s *SyncList) PushBack(v interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.linear.PushBack(v)
+}
+
+func (s *SyncList) PopFront() (interface{}, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.linear.PopFront()
+}
+
+func (s *SyncList) PopBack() (interface{}, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.linear.PopBack()
+}
+
+func (s *SyncList) Append(v interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.linear.Append(v)
+}
+
+func (s *SyncList) Concatenate(o interface{}) {
+	o = unshared(o)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.linear.Concatenate(o)
+}
+
+func (s *SyncList) Insert(i int, o interface{}) {
+	o = unshared(o)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.linear.Insert(i, o)
+}
+
+func (s *SyncList) InsertE(i int, o interface{}) error {
+	o = unshared(o)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.linear.InsertE(i, o)
+}
+
+func (s *SyncList) Delete(from, to int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.linear.Delete(from, to)
+}
+
+func (s *SyncList) DeleteE(from, to int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.linear.DeleteE(from, to)
+}
+
+func (s *SyncList) Cut(from, to int) LinearList {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.linear.Cut(from, to)
+}
+
+func (s *SyncList) CutE(from, to int) (LinearList, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.linear.CutE(from, to)
+}
+
+func (s *SyncList) Absorb(i int, o *LinearList) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.linear.Absorb(i, o)
+}
+
+func (s *SyncList) AbsorbE(i int, o *LinearList) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.linear.AbsorbE(i, o)
+}
+
+func (s *SyncList) Erase() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.linear.Erase()
+}
+
+func (s *SyncList) Reverse() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.linear.Reverse()
+}
+
+func (s *SyncList) SortedInsert(v interface{}, less func(a, b interface{}) bool) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.linear.SortedInsert(v, less)
+}
+
+func (s *SyncList) Validate() error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.Validate()
+}
+
+func (s *SyncList) Debug(on bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.linear.Debug(on)
+}
+
+//	Determines whether o is a LinearList or SyncList holding the same elements
+func (s *SyncList) Equal(o interface{}) bool {
+	o = unshared(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.Equal(o)
+}
+
+func (s *SyncList) DeepEqual(o interface{}) bool {
+	o = unshared(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.DeepEqual(o)
+}
+
+func (s *SyncList) EqualFunc(o interface{}, eq func(a, b interface{}) bool) bool {
+	o = unshared(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.EqualFunc(o, eq)
+}
+
+//	Returns a copy of the list which is not synchronised
+func (s *SyncList) Clone() *LinearList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.Clone()
+}
+
+func (s *SyncList) Map(f func(interface{}) interface{}) *LinearList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.Map(f)
+}
+
+func (s *SyncList) Filter(f func(interface{}) bool) *LinearList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.Filter(f)
+}
+
+func (s *SyncList) Reject(f func(interface{}) bool) *LinearList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.Reject(f)
+}
+
+func (s *SyncList) FlatMap(f func(interface{}) interface{}) *LinearList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.FlatMap(f)
+}
+
+func (s *SyncList) Flattened() *LinearList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.Flattened()
+}
+
+func (s *SyncList) FlattenDepth(n int) *LinearList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.FlattenDepth(n)
+}
+
+func (s *SyncList) FlattenFunc(f func(interface{}) bool) *LinearList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.FlattenFunc(f)
+}
+
+func (s *SyncList) Unique() *LinearList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.Unique()
+}
+
+func (s *SyncList) Union(o Iterable) *LinearList {
+	o = unsharedIterable(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.Union(o)
+}
+
+func (s *SyncList) Intersection(o Iterable) *LinearList {
+	o = unsharedIterable(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.Intersection(o)
+}
+
+func (s *SyncList) Difference(o Iterable) *LinearList {
+	o = unsharedIterable(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.Difference(o)
+}
+
+func (s *SyncList) SymmetricDifference(o Iterable) *LinearList {
+	o = unsharedIterable(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.SymmetricDifference(o)
+}
+
+func (s *SyncList) UniqueHash() *LinearList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.UniqueHash()
+}
+
+func (s *SyncList) UnionHash(o Iterable) *LinearList {
+	o = unsharedIterable(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.UnionHash(o)
+}
+
+func (s *SyncList) IntersectionHash(o Iterable) *LinearList {
+	o = unsharedIterable(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.IntersectionHash(o)
+}
+
+func (s *SyncList) DifferenceHash(o Iterable) *LinearList {
+	o = unsharedIterable(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.DifferenceHash(o)
+}
+
+func (s *SyncList) SymmetricDifferenceHash(o Iterable) *LinearList {
+	o = unsharedIterable(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.SymmetricDifferenceHash(o)
+}
+
+func (s *SyncList) MarshalJSON() ([]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.MarshalJSON()
+}
+
+func (s *SyncList) UnmarshalJSON(data []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.linear.UnmarshalJSON(data)
+}
+
+func (s *SyncList) MarshalBinary() ([]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.linear.MarshalBinary()
+}
+
+func (s *SyncList) UnmarshalBinary(b []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.linear.UnmarshalBinary(b)
+}
+
+func (s *SyncList) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+func (s *SyncList) GobDecode(b []byte) error {
+	return s.UnmarshalBinary(b)
+}
+
+
+//	A SyncCycList is a CycList which may be shared between goroutines
+type SyncCycList struct {
+	syncHeader
+	cyclic		*CycList
+}
+
+//	Wraps a CycList for use by several goroutines. The loop should not be used directly once wrapped.
+func NewSyncCycList(c *CycList) (s *SyncCycList) {
+	if c == nil {
+		c = Loop()
+	}
+	s = &SyncCycList{ cyclic: c }
+	s.list = &c.ListHeader
+	return
+}
+
+//	Returns an immutable copy of the loop which can be read without holding the lock
+func (s *SyncCycList) Snapshot() *Snapshot {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return newSnapshot(s.list, true)
+}
+
+func (s *SyncCycList) String() string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.String()
+}
+
+func (s *SyncCycList) At(i int) interface{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return CycList{ s.uncached() }.At(i)
+}
+
+func (s *SyncCycList) AtE(i int) (interface{}, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return CycList{ s.uncached() }.AtE(i)
+}
+
+func (s *SyncCycList) Set(i int, v interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cyclic.Set(i, v)
+}
+
+func (s *SyncCycList) SetE(i int, v interface{}) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cyclic.SetE(i, v)
+}
+
+func (s *SyncCycList) Clear(i int) {
+	s.Set(i, nil)
+}
+
+//	Replaces the element at i, which wraps around the loop, with the result of applying f to it.
+//	No other goroutine can use the loop between f reading the element and its result being stored.
+func (s *SyncCycList) Update(i int, f func(interface{}) interface{}) (ok bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.cyclic.length > 0 {
+		n := s.cyclic.findNode(s.cyclic.index(i))
+		n.Set(chain.CURRENT_NODE, f(n.Content()))
+		ok = true
+	}
+	return
+}
+
+//	Sets the element at i, which wraps around the loop, to v provided that it currently holds a value equal to old.
+//	Values are compared as they are by Equal.
+func (s *SyncCycList) CompareAndSet(i int, old, v interface{}) (ok bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.cyclic.length > 0 {
+		if n := s.cyclic.findNode(s.cyclic.index(i)); equalValues(n.Content(), old) {
+			n.Set(chain.CURRENT_NODE, v)
+			ok = true
+		}
+	}
+	return
+}
+
+//	Calls f with a Cursor positioned at the given offset, which wraps around the loop, whilst holding the write lock.
+//	Reports whether there is such a position. The Cursor must not be kept after f returns.
+func (s *SyncCycList) Cursor(i int, f func(*Cursor)) (ok bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if c := s.cyclic.Cursor(i); c != nil {
+		f(c)
+		ok = true
+	}
+	return
+}
+
+func (s *SyncCycList) Tail() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cyclic.Tail()
+}
+
+func (s *SyncCycList) Expand(i, n int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cyclic.Expand(i, n)
+}
+
+func (s *SyncCycList) PushFront(v interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cyclic.PushFront(v)
+}
+
+func (s *SyncCycList) PushBack(v interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cyclic.PushBack(v)
+}
+
+func (s *SyncCycList) PopFront() (interface{}, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cyclic.PopFront()
+}
+
+func (s *SyncCycList) PopBack() (interface{}, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cyclic.PopBack()
+}
+
+func (s *SyncCycList) Append(v interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cyclic.Append(v)
+}
+
+func (s *SyncCycList) Concatenate(o interface{}) {
+	o = unshared(o)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cyclic.Concatenate(o)
+}
+
+func (s *SyncCycList) Insert(i int, o interface{}) {
+	o = unshared(o)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cyclic.Insert(i, o)
+}
+
+func (s *SyncCycList) InsertE(i int, o interface{}) error {
+	o = unshared(o)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cyclic.InsertE(i, o)
+}
+
+func (s *SyncCycList) Delete(from, to int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cyclic.Delete(from, to)
+}
+
+func (s *SyncCycList) DeleteE(from, to int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cyclic.DeleteE(from, to)
+}
+
+func (s *SyncCycList) Cut(from, to int) CycList {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cyclic.Cut(from, to)
+}
+
+func (s *SyncCycList) CutE(from, to int) (CycList, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cyclic.CutE(from, to)
+}
+
+func (s *SyncCycList) Absorb(i int, o *CycList) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cyclic.Absorb(i, o)
+}
+
+func (s *SyncCycList) AbsorbE(i int, o *CycList) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cyclic.AbsorbE(i, o)
+}
+
+//	Cuts the loop as CycList.Split does, keeping the elements from i up to but not including j in the synchronised
+//	loop, which is returned as r, and returning the remaining elements as a loop which is not synchronised
+func (s *SyncCycList) Split(i, j int) (r *SyncCycList, o *CycList) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	_, o = s.cyclic.Split(i, j)
+	return s, o
+}
+
+//	Splices another loop into the loop before the element at the given offset, leaving the other loop empty
+func (s *SyncCycList) Join(o *CycList, at int) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cyclic.Join(o, at)
+}
+
+func (s *SyncCycList) Step(k int) (interface{}, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cyclic.Step(k)
+}
+
+//	Removes every k-th element as CycList.RemoveEvery does. As visit is called whilst the write lock is held it must
+//	not use the same SyncCycList.
+func (s *SyncCycList) RemoveEvery(k int, visit func(interface{}) bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cyclic.RemoveEvery(k, visit)
+}
+
+//	Iterates over the elements of a copy of the loop indefinitely, so that the lock is not held whilst f is called.
+//	As with CycList.Cycle, iteration only ends when f panics.
+func (s *SyncCycList) Cycle(f func(interface{})) {
+	s.mutex.RLock()
+	c := s.cyclic.Clone()
+	s.mutex.RUnlock()
+	c.Cycle(f)
+}
+
+//	Returns an iterator which cycles indefinitely over a copy of the loop taken when Cycling is called
+func (s *SyncCycList) Cycling() iter.Seq[interface{}] {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.Clone().Cycling()
+}
+
+func (s *SyncCycList) Erase() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cyclic.Erase()
+}
+
+func (s *SyncCycList) Reverse() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cyclic.Reverse()
+}
+
+func (s *SyncCycList) Rotate(i int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cyclic.Rotate(i)
+}
+
+func (s *SyncCycList) Canonicalize(less func(a, b interface{}) bool) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cyclic.Canonicalize(less)
+}
+
+func (s *SyncCycList) SortedInsert(v interface{}, less func(a, b interface{}) bool) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cyclic.SortedInsert(v, less)
+}
+
+func (s *SyncCycList) Validate() error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.Validate()
+}
+
+func (s *SyncCycList) Debug(on bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cyclic.Debug(on)
+}
+
+//	Determines whether o is a CycList or SyncCycList holding the same elements
+func (s *SyncCycList) Equal(o interface{}) bool {
+	o = unshared(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.Equal(o)
+}
+
+func (s *SyncCycList) DeepEqual(o interface{}) bool {
+	o = unshared(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.DeepEqual(o)
+}
+
+func (s *SyncCycList) EqualFunc(o interface{}, eq func(a, b interface{}) bool) bool {
+	o = unshared(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.EqualFunc(o, eq)
+}
+
+func (s *SyncCycList) EquivalentRotation(o *CycList) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.EquivalentRotation(o)
+}
+
+func (s *SyncCycList) RotationOffset(o *CycList) (int, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.RotationOffset(o)
+}
+
+//	Returns a copy of the loop which is not synchronised
+func (s *SyncCycList) Clone() *CycList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.Clone()
+}
+
+func (s *SyncCycList) Map(f func(interface{}) interface{}) *CycList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.Map(f)
+}
+
+func (s *SyncCycList) Filter(f func(interface{}) bool) *CycList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.Filter(f)
+}
+
+func (s *SyncCycList) Reject(f func(interface{}) bool) *CycList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.Reject(f)
+}
+
+func (s *SyncCycList) FlatMap(f func(interface{}) interface{}) *CycList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.FlatMap(f)
+}
+
+func (s *SyncCycList) Flattened() *CycList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.Flattened()
+}
+
+func (s *SyncCycList) FlattenDepth(n int) *CycList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.FlattenDepth(n)
+}
+
+func (s *SyncCycList) FlattenFunc(f func(interface{}) bool) *CycList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.FlattenFunc(f)
+}
+
+func (s *SyncCycList) Unique() *CycList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.Unique()
+}
+
+func (s *SyncCycList) Union(o Iterable) *CycList {
+	o = unsharedIterable(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.Union(o)
+}
+
+func (s *SyncCycList) Intersection(o Iterable) *CycList {
+	o = unsharedIterable(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.Intersection(o)
+}
+
+func (s *SyncCycList) Difference(o Iterable) *CycList {
+	o = unsharedIterable(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.Difference(o)
+}
+
+func (s *SyncCycList) SymmetricDifference(o Iterable) *CycList {
+	o = unsharedIterable(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.SymmetricDifference(o)
+}
+
+func (s *SyncCycList) UniqueHash() *CycList {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.UniqueHash()
+}
+
+func (s *SyncCycList) UnionHash(o Iterable) *CycList {
+	o = unsharedIterable(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.UnionHash(o)
+}
+
+func (s *SyncCycList) IntersectionHash(o Iterable) *CycList {
+	o = unsharedIterable(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.IntersectionHash(o)
+}
+
+func (s *SyncCycList) DifferenceHash(o Iterable) *CycList {
+	o = unsharedIterable(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.DifferenceHash(o)
+}
+
+func (s *SyncCycList) SymmetricDifferenceHash(o Iterable) *CycList {
+	o = unsharedIterable(o)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.SymmetricDifferenceHash(o)
+}
+
+func (s *SyncCycList) MarshalJSON() ([]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.MarshalJSON()
+}
+
+func (s *SyncCycList) UnmarshalJSON(data []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cyclic.UnmarshalJSON(data)
+}
+
+func (s *SyncCycList) MarshalBinary() ([]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.cyclic.MarshalBinary()
+}
+
+func (s *SyncCycList) UnmarshalBinary(b []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cyclic.UnmarshalBinary(b)
+}
+
+func (s *SyncCycList) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+func (s *SyncCycList) GobDecode(b []byte) error {
+	return s.UnmarshalBinary(b)
+}
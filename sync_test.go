@@ -0,0 +1,410 @@
+package lists
+
+import "iter"
+import "reflect"
+import "sync"
+import "testing"
+
+func TestSyncList(t *testing.T) {
+	s := NewSyncList(List(0, 1, 2))
+	s.Append(3)
+	s.Insert(0, -1)
+	s.Set(1, 10)
+	switch {
+	case s.Len() != 5:						t.Fatalf("Len() should be 5 not %v", s.Len())
+	case s.At(1) != 10:						t.Fatalf("At(1) should be 10 not %v", s.At(1))
+	case !s.Equal(List(-1, 10, 1, 2, 3)):	t.Fatalf("%v should be (-1 10 1 2 3)", s)
+	case s.String() != "(-1 10 1 2 3)":		t.Fatalf("String() should be (-1 10 1 2 3) not %v", s)
+	}
+	if _, e := s.AtE(5); e != (IndexOutOfRange{ 5, 5 }) {
+		t.Fatalf("AtE(5) should fail not %v", e)
+	}
+
+	s.Concatenate(s)
+	if !s.Equal(List(-1, 10, 1, 2, 3, -1, 10, 1, 2, 3)) {
+		t.Fatalf("Concatenate() should copy a SyncList passed to itself not %v", s)
+	}
+	if x := s.Intersection(NewSyncList(List(1, 3, 5))); !x.Equal(List(1, 3)) {
+		t.Fatalf("Intersection() should be (1 3) not %v", x)
+	}
+	if !s.Equal(s) {
+		t.Fatalf("a SyncList should equal itself")
+	}
+
+	var q Sequence = NewSyncList(nil)
+	if q.Len() != 0 {
+		t.Fatalf("a SyncList should be an empty Sequence")
+	}
+}
+
+func TestSyncListUpdate(t *testing.T) {
+	s := NewSyncList(List(0, 0))
+	var w sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		w.Add(2)
+		go func() {
+			defer w.Done()
+			s.Update(0, func(v interface{}) interface{} { return v.(int) + 1 })
+		}()
+		go func() {
+			defer w.Done()
+			for {
+				v := s.At(1)
+				if s.CompareAndSet(1, v, v.(int) + 1) {
+					return
+				}
+			}
+		}()
+	}
+	w.Wait()
+	if !s.Equal(List(50, 50)) {
+		t.Fatalf("every update should be counted, not %v", s)
+	}
+	if s.Update(2, twice) {
+		t.Fatalf("Update() should fail for a missing element")
+	}
+	switch {
+	case s.CompareAndSet(0, 49, 0):		t.Fatalf("CompareAndSet() should fail when the element differs")
+	case !s.CompareAndSet(0, 50, 0):	t.Fatalf("CompareAndSet() should succeed when the element matches")
+	case s.At(0) != 0:					t.Fatalf("CompareAndSet() should store the new value not %v", s.At(0))
+	}
+}
+
+func TestSyncListPositional(t *testing.T) {
+	s := NewSyncList(List(0, 1, 2, 3))
+	if s.Head() != 0 {
+		t.Fatalf("Head() should be 0 not %v", s.Head())
+	}
+	s.Tail()
+	s.Expand(1, 2)
+	if !s.Equal(List(1, nil, nil, 2, 3)) {
+		t.Fatalf("Tail() and Expand() should give %v not %v", List(1, nil, nil, 2, 3), s)
+	}
+
+	ok := s.Cursor(1, func(c *Cursor) {
+		c.Remove()
+		c.Set(10)
+	})
+	switch {
+	case !ok:								t.Fatalf("Cursor(1) should succeed")
+	case !s.Equal(List(1, 10, 2, 3)):		t.Fatalf("edits through Cursor() should give %v not %v", List(1, 10, 2, 3), s)
+	case s.Cursor(5, func(*Cursor) {}):		t.Fatalf("Cursor(5) should fail")
+	}
+
+	v := s.Slice(1, 5)
+	s.Set(1, 20)
+	switch {
+	case v.Check() != nil:					t.Fatalf("Slice() should not become stale: %v", v.Check())
+	case v.String() != "(10 2 3)":			t.Fatalf("Slice(1, 5) should be (10 2 3) not %v", v)
+	}
+	v.Set(0, 0)
+	if s.At(1) != 20 {
+		t.Fatalf("setting an element of Slice() should not alter the list")
+	}
+	if v := s.Slice(3, 1); v.Len() != 0 {
+		t.Fatalf("Slice(3, 1) should be empty not %v", v)
+	}
+
+	ConfirmBackward := func(b interface{ Backward() iter.Seq2[int, interface{}] }, r []interface{}) {
+		x := []interface{}{}
+		for i, v := range b.Backward() {
+			if i != len(r) - len(x) - 1 {
+				t.Fatalf("Backward() should give index %v not %v", len(r) - len(x) - 1, i)
+			}
+			x = append(x, v)
+		}
+		if !List(x...).Equal(List(r...)) {
+			t.Fatalf("Backward() should give %v not %v", r, x)
+		}
+	}
+	p := s.Snapshot()
+	for range s.Backward() {
+		s.Append(4)
+	}
+	ConfirmBackward(p, []interface{}{ 3, 2, 20, 1 })
+	ConfirmBackward(s, []interface{}{ 4, 4, 4, 4, 3, 2, 20, 1 })
+}
+
+//	Lookups share the read lock, so they can be made by a goroutine which already holds it and leave the cache untouched
+func TestSyncListReadLock(t *testing.T) {
+	s := NewSyncList(List(0, 1, 2, 3, 4))
+	stats := s.PositionStats()
+	s.mutex.RLock()
+	switch {
+	case s.At(2) != 2:						t.Fatalf("At(2) should be 2 not %v", s.At(2))
+	case s.Head() != 0:						t.Fatalf("Head() should be 0 not %v", s.Head())
+	}
+	if v, e := s.AtE(3); e != nil || v != 3 {
+		t.Fatalf("AtE(3) should be 3 not %v: %v", v, e)
+	}
+	s.mutex.RUnlock()
+	if s.PositionStats() != stats {
+		t.Fatalf("lookups should not use the PositionCache")
+	}
+
+	c := NewSyncCycList(Loop(0, 1, 2))
+	c.mutex.RLock()
+	if c.At(4) != 1 {
+		t.Fatalf("At(4) should wrap to 1 not %v", c.At(4))
+	}
+	c.mutex.RUnlock()
+}
+
+func TestSyncListConcurrency(t *testing.T) {
+	s := NewSyncList(List())
+	var w sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		w.Add(1)
+		go func(i int) {
+			defer w.Done()
+			for j := 0; j < 100; j++ {
+				s.Append(j)
+				s.At(s.Len() / 2)
+				s.Snapshot().Each(func(interface{}) {})
+				if j % 10 == 0 {
+					s.Delete(0, 0)
+				}
+			}
+		}(i)
+	}
+	w.Wait()
+	if s.Len() != 8 * 90 {
+		t.Fatalf("Len() should be %v not %v", 8 * 90, s.Len())
+	}
+	if e := s.Validate(); e != nil {
+		t.Fatalf("concurrent use should leave a valid list: %v", e)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	s := NewSyncList(List(0, 1, 2))
+	p := s.Snapshot()
+	s.Append(3)
+	s.Set(0, 10)
+	switch {
+	case !p.Equal(List(0, 1, 2)):	t.Fatalf("Snapshot() should be unaffected by later changes not %v", p)
+	case p.At(2) != 2:				t.Fatalf("At(2) should be 2 not %v", p.At(2))
+	case p.At(3) != nil:			t.Fatalf("At(3) should be nil not %v", p.At(3))
+	}
+
+	l := p.List()
+	l.Append(3)
+	if p.Len() != 3 {
+		t.Fatalf("List() should return a copy")
+	}
+
+	var w sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		w.Add(1)
+		go func() {
+			defer w.Done()
+			for j := 0; j < 100; j++ {
+				p.At(j % 3)
+				for range p.Values() {}
+			}
+		}()
+	}
+	w.Wait()
+}
+
+func TestSyncCycList(t *testing.T) {
+	s := NewSyncCycList(Loop(0, 1, 2))
+	switch {
+	case s.At(4) != 1:					t.Fatalf("At(4) should wrap to 1 not %v", s.At(4))
+	case !s.Update(-1, twice):			t.Fatalf("Update(-1) should wrap onto the last element")
+	case !s.Equal(Loop(0, 1, 4)):		t.Fatalf("%v should be %v", s, Loop(0, 1, 4))
+	case !s.CompareAndSet(5, 4, 2):		t.Fatalf("CompareAndSet(5) should wrap onto the last element")
+	}
+	s.Rotate(1)
+	if !s.Equal(Loop(1, 2, 0)) {
+		t.Fatalf("Rotate(1) should be %v not %v", Loop(1, 2, 0), s)
+	}
+
+	p := s.Snapshot()
+	s.Append(3)
+	switch {
+	case !p.Equal(Loop(1, 2, 0)):		t.Fatalf("Snapshot() should be %v not %v", Loop(1, 2, 0), p)
+	case p.At(3) != 1:					t.Fatalf("At(3) should wrap to 1 not %v", p.At(3))
+	case p.Loop().At(4) != 2:			t.Fatalf("Loop() should return a closed loop")
+	case !s.Equal(s):					t.Fatalf("a SyncCycList should equal itself")
+	}
+
+	e := NewSyncCycList(nil)
+	switch {
+	case e.String() != "(...)":				t.Fatalf("an empty SyncCycList should be written as (...) not %v", e)
+	case e.Snapshot().String() != "(...)":	t.Fatalf("a Snapshot of an empty loop should be written as (...) not %v", e.Snapshot())
+	case e.Update(0, twice):				t.Fatalf("Update() should fail for an empty loop")
+	case e.CompareAndSet(0, nil, 1):		t.Fatalf("CompareAndSet() should fail for an empty loop")
+	}
+}
+
+func TestSyncCycListPositional(t *testing.T) {
+	s := NewSyncCycList(Loop(0, 1, 2, 3))
+	s.Tail()
+	s.Expand(-1, 1)
+	switch {
+	case s.Head() != 1:						t.Fatalf("Head() should be 1 not %v", s.Head())
+	case !s.Equal(Loop(1, 2, nil, 3)):		t.Fatalf("Tail() and Expand() should give %v not %v", Loop(1, 2, nil, 3), s)
+	case s.Validate() != nil:				t.Fatalf("Expand() should leave a closed loop: %v", s.Validate())
+	}
+
+	ok := s.Cursor(-2, func(c *Cursor) {
+		c.Remove()
+		c.InsertAfter(4)
+	})
+	switch {
+	case !ok:								t.Fatalf("Cursor(-2) should succeed")
+	case !s.Equal(Loop(1, 2, 3, 4)):		t.Fatalf("edits through Cursor() should give %v not %v", Loop(1, 2, 3, 4), s)
+	case s.Validate() != nil:				t.Fatalf("edits through Cursor() should leave a closed loop: %v", s.Validate())
+	}
+
+	if v := s.Slice(2, 3); v.String() != "(3 4)" {
+		t.Fatalf("Slice(2, 3) should be (3 4) not %v", v)
+	}
+	x := []interface{}{}
+	for _, v := range s.Backward() {
+		x = append(x, v)
+	}
+	if !List(x...).Equal(List(4, 3, 2, 1)) {
+		t.Fatalf("Backward() should give (4 3 2 1) not %v", x)
+	}
+	x = x[:0]
+	for _, v := range s.Snapshot().Backward() {
+		x = append(x, v)
+	}
+	if !List(x...).Equal(List(4, 3, 2, 1)) {
+		t.Fatalf("Snapshot().Backward() should give (4 3 2 1) not %v", x)
+	}
+}
+
+//	Every method of LinearList and CycList should have a synchronised counterpart
+func TestSyncMethodSets(t *testing.T) {
+	ConfirmMethods := func(l, s interface{}) {
+		lt, st := reflect.TypeOf(l), reflect.TypeOf(s)
+		for i := 0; i < lt.NumMethod(); i++ {
+			if _, ok := st.MethodByName(lt.Method(i).Name); !ok {
+				t.Errorf("%v lacks %v", st, lt.Method(i).Name)
+			}
+		}
+	}
+	ConfirmMethods(&LinearList{}, &SyncList{})
+	ConfirmMethods(&CycList{}, &SyncCycList{})
+}
+
+func TestSyncListDeque(t *testing.T) {
+	s := NewSyncList(List(1))
+	s.PushFront(0)
+	s.PushBack(2)
+	if v, ok := s.PeekFront(); v != 0 || !ok {
+		t.Fatalf("PeekFront() should be 0 not %v", v)
+	}
+	if v, ok := s.PeekBack(); v != 2 || !ok {
+		t.Fatalf("PeekBack() should be 2 not %v", v)
+	}
+	switch {
+	case s.Start().Content() != 0:			t.Fatalf("Start() should hold 0 not %v", s.Start().Content())
+	case s.End().Content() != 2:			t.Fatalf("End() should hold 2 not %v", s.End().Content())
+	case s.CheckBounds(0, 3) == nil:		t.Fatalf("CheckBounds(0, 3) should fail")
+	}
+
+	x := []interface{}{}
+	for i, v := range s.All() {
+		s.PushBack(i)
+		x = append(x, v)
+	}
+	if !List(x...).Equal(List(0, 1, 2)) || !s.Equal(List(0, 1, 2, 0, 1, 2)) {
+		t.Fatalf("All() should iterate over a copy of the list, not give %v leaving %v", x, s)
+	}
+	x = x[:0]
+	for v := range s.Values() {
+		x = append(x, v)
+	}
+	if !List(x...).Equal(List(0, 1, 2, 0, 1, 2)) {
+		t.Fatalf("Values() should give %v not %v", List(0, 1, 2, 0, 1, 2), x)
+	}
+
+	if v, ok := s.PopFront(); v != 0 || !ok {
+		t.Fatalf("PopFront() should be 0 not %v", v)
+	}
+	if v, ok := s.PopBack(); v != 2 || !ok || !s.Equal(List(1, 2, 0, 1)) {
+		t.Fatalf("PopBack() should be 2 leaving %v not %v leaving %v", List(1, 2, 0, 1), v, s)
+	}
+}
+
+func TestSyncCycListEditing(t *testing.T) {
+	s := NewSyncCycList(Loop(1, 2))
+	s.PushFront(0)
+	s.PushBack(3)
+	s.Insert(-1, 10)
+	if e := s.InsertE(1, NewSyncCycList(Loop(20))); e != nil {
+		t.Fatalf("InsertE(1) should succeed: %v", e)
+	}
+	s.Delete(-2, -2)
+	switch {
+	case !s.Equal(Loop(0, Loop(20), 1, 2, 3)):	t.Fatalf("editing should give %v not %v", Loop(0, Loop(20), 1, 2, 3), s)
+	case s.DeleteE(1, 1) != nil:				t.Fatalf("DeleteE(1, 1) should succeed")
+	case s.Validate() != nil:					t.Fatalf("editing should leave a closed loop: %v", s.Validate())
+	}
+
+	r := s.Cut(3, 0)
+	if !r.Equal(Loop(3, 0)) || !s.Equal(Loop(1, 2)) {
+		t.Fatalf("Cut(3, 0) should be %v leaving %v not %v leaving %v", Loop(3, 0), Loop(1, 2), r, s)
+	}
+	if _, e := NewSyncCycList(nil).CutE(0, 0); e == nil {
+		t.Fatalf("CutE(0, 0) of an empty loop should fail")
+	}
+	if !s.Absorb(0, &r) || s.AbsorbE(4, Loop(4)) != nil || !s.Equal(Loop(3, 0, 1, 2, 4)) {
+		t.Fatalf("Absorb() should give %v not %v", Loop(3, 0, 1, 2, 4), s)
+	}
+
+	x, o := s.Split(1, 3)
+	switch {
+	case x != s:								t.Fatalf("Split() should return the SyncCycList itself")
+	case !s.Equal(Loop(0, 1)):					t.Fatalf("Split(1, 3) should leave %v not %v", Loop(0, 1), s)
+	case !o.Equal(Loop(2, 4, 3)):				t.Fatalf("Split(1, 3) should give %v not %v", Loop(2, 4, 3), o)
+	case !s.Join(o, 1):							t.Fatalf("Join() should succeed")
+	case !s.Equal(Loop(0, 2, 4, 3, 1)):			t.Fatalf("Join(o, 1) should give %v not %v", Loop(0, 2, 4, 3, 1), s)
+	}
+
+	if v, ok := s.Step(2); v != 4 || !ok {
+		t.Fatalf("Step(2) should be 4 not %v", v)
+	}
+	removed := []interface{}{}
+	s.RemoveEvery(2, func(v interface{}) bool {
+		removed = append(removed, v)
+		return len(removed) < 2
+	})
+	if !List(removed...).Equal(List(3, 0)) || !s.Equal(Loop(2, 4, 1)) {
+		t.Fatalf("RemoveEvery(2) should remove (3 0) leaving %v not %v leaving %v", Loop(2, 4, 1), removed, s)
+	}
+
+	cycled := []interface{}{}
+	for v := range s.Cycling() {
+		if s.Append(5); len(cycled) == 5 {
+			break
+		}
+		cycled = append(cycled, v)
+	}
+	if !List(cycled...).Equal(List(2, 4, 1, 2, 4)) {
+		t.Fatalf("Cycling() should cycle over a copy of the loop, not give %v", cycled)
+	}
+
+	type finished struct{}
+	cycled = cycled[:0]
+	func() {
+		defer func() {
+			if x := recover(); x != (finished{}) {
+				panic(x)
+			}
+		}()
+		s.Cycle(func(v interface{}) {
+			if s.Append(6); len(cycled) == 10 {
+				panic(finished{})
+			}
+			cycled = append(cycled, v)
+		})
+	}()
+	if len(cycled) != 10 || cycled[9] != 2 || s.Len() != 20 {
+		t.Fatalf("Cycle() should cycle over a copy of the loop without holding the lock, not give %v", cycled)
+	}
+}
@@ -0,0 +1,739 @@
+package lists
+
+import "github.com/feyeleanor/chain"
+import "fmt"
+import "iter"
+import "reflect"
+
+/*
+	The typed lists wrap the interface{} based lists so that their contents can be accessed
+	as values of a known type without repeated type assertions by the caller.
+
+	Each typed list shares its nodes with an underlying untyped list which can be retrieved
+	with Untyped(), so the two forms can be freely mixed. An element which is nil reads as the
+	zero value of the list's type, but an element of any other type can only have been stored
+	through the untyped list and reading it panics with a TypeMismatch. The checked AtE()
+	reports a TypeMismatch as an error instead.
+
+	The typed lists offer every operation of the untyped lists except those which deal in
+	nodes (NewListNode, Cursor, Slice), in nested lists (the Flatten family and FlatMap) or in
+	encoding settings and binary encodings, which are reached through Untyped().
+*/
+
+//	A TypeMismatch reports an element of a typed list which does not hold a value of the list's type
+type TypeMismatch struct {
+	Value		interface{}
+	Type		reflect.Type
+}
+
+func (e TypeMismatch) Error() string {
+	return fmt.Sprintf("lists: element %v of type %T is not a %v", e.Value, e.Value, e.Type)
+}
+
+//	Converts an element to the list's type, returning the zero value for nil and a TypeMismatch for an element of another type
+func typedE[T any](v interface{}) (r T, e error) {
+	if v != nil {
+		var ok bool
+		if r, ok = v.(T); !ok {
+			e = TypeMismatch{ v, reflect.TypeOf((*T)(nil)).Elem() }
+		}
+	}
+	return
+}
+
+//	Converts an element to the list's type, panicking with a TypeMismatch for an element of another type
+func typed[T any](v interface{}) (r T) {
+	var e error
+	if r, e = typedE[T](v); e != nil {
+		panic(e)
+	}
+	return
+}
+
+type TypedHeader[T any] struct {
+	header		*ListHeader
+}
+
+func NewTypedHeader[T any](n chain.Node) TypedHeader[T] {
+	h := NewListHeader(n)
+	return TypedHeader[T]{ &h }
+}
+
+func (l TypedHeader[T]) Untyped() *ListHeader {
+	return l.header
+}
+
+func (l TypedHeader[T]) Len() int {
+	return l.header.Len()
+}
+
+func (l TypedHeader[T]) Start() chain.Node {
+	return l.header.Start()
+}
+
+func (l TypedHeader[T]) End() chain.Node {
+	return l.header.End()
+}
+
+func (l TypedHeader[T]) String() string {
+	return l.header.String()
+}
+
+func (l TypedHeader[T]) At(i int) T {
+	return typed[T](l.header.At(i))
+}
+
+func (l TypedHeader[T]) Set(i int, v T) {
+	l.header.Set(i, v)
+}
+
+func (l TypedHeader[T]) Each(f func(T)) {
+	l.header.Each(func(v interface{}) {
+		f(typed[T](v))
+	})
+}
+
+func (l TypedHeader[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range l.header.All() {
+			if !yield(i, typed[T](v)) {
+				return
+			}
+		}
+	}
+}
+
+func (l TypedHeader[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range l.header.Values() {
+			if !yield(typed[T](v)) {
+				return
+			}
+		}
+	}
+}
+
+func (l TypedHeader[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range l.header.Backward() {
+			if !yield(i, typed[T](v)) {
+				return
+			}
+		}
+	}
+}
+
+func (l TypedHeader[T]) Compact() (r []T) {
+	r = make([]T, 0, l.header.Len())
+	l.header.Each(func(v interface{}) {
+		r = append(r, typed[T](v))
+	})
+	return
+}
+
+func (l TypedHeader[T]) Append(v T) {
+	l.header.Append(v)
+}
+
+func (l TypedHeader[T]) Concatenate(s []T) {
+	l.header.Concatenate(s)
+}
+
+func (l TypedHeader[T]) Reverse() {
+	l.header.Reverse()
+}
+
+func (l TypedHeader[T]) untypedLess(less func(a, b T) bool) func(a, b interface{}) bool {
+	return func(a, b interface{}) bool {
+		return less(typed[T](a), typed[T](b))
+	}
+}
+
+func (l TypedHeader[T]) Sort(less func(a, b T) bool) {
+	l.header.Sort(l.untypedLess(less))
+}
+
+func (l TypedHeader[T]) SortStable(less func(a, b T) bool) {
+	l.header.SortStable(l.untypedLess(less))
+}
+
+func (l TypedHeader[T]) IsSorted(less func(a, b T) bool) bool {
+	return l.header.IsSorted(l.untypedLess(less))
+}
+
+func (l TypedHeader[T]) Clone() TypedHeader[T] {
+	return TypedHeader[T]{ l.header.Clone() }
+}
+
+func (l TypedHeader[T]) Equal(o interface{}) (r bool) {
+	switch o := o.(type) {
+	case TypedHeader[T]:	r = l.header.Equal(o.header)
+	default:				r = l.header.Equal(o)
+	}
+	return
+}
+
+
+//	Replaces a typed list or loop of the same element type with the untyped list it wraps
+func (l TypedHeader[T]) unwrap(o interface{}) interface{} {
+	switch x := o.(type) {
+	case *TypedList[T]:		if x != nil {
+								o = x.list
+							}
+	case TypedList[T]:		o = x.list
+	case *TypedLoop[T]:		if x != nil {
+								o = x.loop
+							}
+	case TypedLoop[T]:		o = x.loop
+	}
+	return o
+}
+
+func (l TypedHeader[T]) untypedTest(f func(T) bool) func(interface{}) bool {
+	return func(v interface{}) bool {
+		return f(typed[T](v))
+	}
+}
+
+func (l TypedHeader[T]) untypedMap(f func(T) T) func(interface{}) interface{} {
+	return func(v interface{}) interface{} {
+		return f(typed[T](v))
+	}
+}
+
+//	Compares elements with eq, treating an element which is not of the list's type as unequal to anything
+func (l TypedHeader[T]) untypedEqual(eq func(a, b T) bool) func(a, b interface{}) bool {
+	return func(a, b interface{}) bool {
+		x, e := typedE[T](a)
+		y, f := typedE[T](b)
+		return e == nil && f == nil && eq(x, y)
+	}
+}
+
+func (l TypedHeader[T]) Head() T {
+	return typed[T](l.header.Head())
+}
+
+func (l TypedHeader[T]) Clear(i int) {
+	l.header.Clear(i)
+}
+
+func (l TypedHeader[T]) Erase() {
+	l.header.Erase()
+}
+
+func (l TypedHeader[T]) CheckBounds(start, end int) error {
+	return l.header.CheckBounds(start, end)
+}
+
+func (l TypedHeader[T]) EnforceBounds(start, end *int) bool {
+	return l.header.EnforceBounds(start, end)
+}
+
+func (l TypedHeader[T]) UsePositionCache(c PositionCache) {
+	l.header.UsePositionCache(c)
+}
+
+func (l TypedHeader[T]) PositionStats() CacheStats {
+	return l.header.PositionStats()
+}
+
+func (l TypedHeader[T]) PeekFront() (r T, ok bool) {
+	var v interface{}
+	if v, ok = l.header.PeekFront(); ok {
+		r = typed[T](v)
+	}
+	return
+}
+
+func (l TypedHeader[T]) PeekBack() (r T, ok bool) {
+	var v interface{}
+	if v, ok = l.header.PeekBack(); ok {
+		r = typed[T](v)
+	}
+	return
+}
+
+func (l TypedHeader[T]) PopFront() (r T, ok bool) {
+	var v interface{}
+	if v, ok = l.header.PopFront(); ok {
+		r = typed[T](v)
+	}
+	return
+}
+
+func (l TypedHeader[T]) PopBack() (r T, ok bool) {
+	var v interface{}
+	if v, ok = l.header.PopBack(); ok {
+		r = typed[T](v)
+	}
+	return
+}
+
+func (l TypedHeader[T]) Any(f func(T) bool) bool {
+	return l.header.Any(l.untypedTest(f))
+}
+
+func (l TypedHeader[T]) Every(f func(T) bool) bool {
+	return l.header.Every(l.untypedTest(f))
+}
+
+func (l TypedHeader[T]) Count(f func(T) bool) int {
+	return l.header.Count(l.untypedTest(f))
+}
+
+func (l TypedHeader[T]) Find(f func(T) bool) (r T, ok bool) {
+	var v interface{}
+	if v, ok = l.header.Find(l.untypedTest(f)); ok {
+		r = typed[T](v)
+	}
+	return
+}
+
+func (l TypedHeader[T]) FindIndex(f func(T) bool) int {
+	return l.header.FindIndex(l.untypedTest(f))
+}
+
+//	Combines the elements from the start of the list with f, returning the zero value for an empty list
+func (l TypedHeader[T]) Reduce(f func(memo, v T) T) T {
+	return typed[T](l.header.Reduce(func(memo, v interface{}) interface{} {
+		return f(typed[T](memo), typed[T](v))
+	}))
+}
+
+//	Combines the elements from the start of the list with f, starting from seed.
+//	As methods cannot introduce type parameters the result is of the list's type; Untyped().FoldLeft() accumulates any other type.
+func (l TypedHeader[T]) FoldLeft(seed T, f func(memo, v T) T) T {
+	return typed[T](l.header.FoldLeft(seed, func(memo, v interface{}) interface{} {
+		return f(typed[T](memo), typed[T](v))
+	}))
+}
+
+//	Combines the elements from the end of the list with f, starting from seed, with a result of the list's type as for FoldLeft
+func (l TypedHeader[T]) FoldRight(seed T, f func(v, memo T) T) T {
+	return typed[T](l.header.FoldRight(seed, func(v, memo interface{}) interface{} {
+		return f(typed[T](v), typed[T](memo))
+	}))
+}
+
+func (l TypedHeader[T]) MapInPlace(f func(T) T) {
+	l.header.MapInPlace(l.untypedMap(f))
+}
+
+func (l TypedHeader[T]) FilterInPlace(f func(T) bool) {
+	l.header.FilterInPlace(l.untypedTest(f))
+}
+
+func (l TypedHeader[T]) RejectInPlace(f func(T) bool) {
+	l.header.RejectInPlace(l.untypedTest(f))
+}
+
+func (l TypedHeader[T]) MarshalJSON() ([]byte, error) {
+	return l.header.MarshalJSON()
+}
+
+
+//	A TypedIterable supplies elements of type T, as the typed lists do, to the typed set operations
+type TypedIterable[T any] interface {
+	Each(func(T))
+}
+
+type untypedIterable[T any] struct {
+	source		TypedIterable[T]
+}
+
+func (u untypedIterable[T]) Each(f func(interface{})) {
+	u.source.Each(func(v T) {
+		f(v)
+	})
+}
+
+//	Presents a TypedIterable as an Iterable, keeping nil as nil so that it is treated as empty
+func untypedSource[T any](o TypedIterable[T]) (r Iterable) {
+	if o != nil {
+		r = untypedIterable[T]{ o }
+	}
+	return
+}
+
+
+//	A declarative method for building TypedLists
+func ListOf[T any](items... T) (l *TypedList[T]) {
+	l = NewTypedList[T](&chain.Cell{})
+	l.Concatenate(items)
+	return
+}
+
+type TypedList[T any] struct {
+	TypedHeader[T]
+	list		*LinearList
+}
+
+func NewTypedList[T any](n chain.Node) *TypedList[T] {
+	return typedList[T](NewLinearList(n))
+}
+
+func typedList[T any](l *LinearList) *TypedList[T] {
+	return &TypedList[T]{ TypedHeader[T]{ &l.ListHeader }, l }
+}
+
+func (l TypedList[T]) Untyped() *LinearList {
+	return l.list
+}
+
+func (l TypedList[T]) Clone() *TypedList[T] {
+	return typedList[T](l.list.Clone())
+}
+
+func (l TypedList[T]) Equal(o interface{}) (r bool) {
+	switch o := o.(type) {
+	case *TypedList[T]:		r = o != nil && l.list.Equal(o.list)
+	case TypedList[T]:		r = l.list.Equal(o.list)
+	default:				r = l.list.Equal(o)
+	}
+	return
+}
+
+func (l *TypedList[T]) Delete(from, to int) {
+	l.list.Delete(from, to)
+}
+
+func (l *TypedList[T]) Cut(from, to int) *TypedList[T] {
+	r := l.list.Cut(from, to)
+	return typedList[T](&r)
+}
+
+func (l *TypedList[T]) Insert(i int, v T) {
+	l.list.Insert(i, v)
+}
+
+func (l *TypedList[T]) SortedInsert(v T, less func(a, b T) bool) int {
+	return l.list.SortedInsert(v, l.untypedLess(less))
+}
+
+func (l *TypedList[T]) Absorb(i int, o *TypedList[T]) (ok bool) {
+	if o != nil {
+		ok = l.list.Absorb(i, o.list)
+	}
+	return
+}
+
+
+func (l *TypedList[T]) Tail() {
+	l.list.Tail()
+}
+
+func (l *TypedList[T]) Expand(i, n int) {
+	l.list.Expand(i, n)
+}
+
+func (l *TypedList[T]) PushFront(v T) {
+	l.list.PushFront(v)
+}
+
+func (l *TypedList[T]) PushBack(v T) {
+	l.list.PushBack(v)
+}
+
+func (l TypedList[T]) Validate() error {
+	return l.list.Validate()
+}
+
+func (l *TypedList[T]) Debug(on bool) {
+	l.list.Debug(on)
+}
+
+func (l TypedList[T]) DeepEqual(o interface{}) bool {
+	return l.list.DeepEqual(l.unwrap(o))
+}
+
+//	Determines whether o holds the same elements in the same order, comparing each pair with eq
+func (l TypedList[T]) EqualFunc(o interface{}, eq func(a, b T) bool) bool {
+	return l.list.EqualFunc(l.unwrap(o), l.untypedEqual(eq))
+}
+
+func (l TypedList[T]) Map(f func(T) T) *TypedList[T] {
+	return typedList[T](l.list.Map(l.untypedMap(f)))
+}
+
+func (l TypedList[T]) Filter(f func(T) bool) *TypedList[T] {
+	return typedList[T](l.list.Filter(l.untypedTest(f)))
+}
+
+func (l TypedList[T]) Reject(f func(T) bool) *TypedList[T] {
+	return typedList[T](l.list.Reject(l.untypedTest(f)))
+}
+
+func (l TypedList[T]) Unique() *TypedList[T] {
+	return typedList[T](l.list.Unique())
+}
+
+func (l TypedList[T]) Union(o TypedIterable[T]) *TypedList[T] {
+	return typedList[T](l.list.Union(untypedSource(o)))
+}
+
+func (l TypedList[T]) Intersection(o TypedIterable[T]) *TypedList[T] {
+	return typedList[T](l.list.Intersection(untypedSource(o)))
+}
+
+func (l TypedList[T]) Difference(o TypedIterable[T]) *TypedList[T] {
+	return typedList[T](l.list.Difference(untypedSource(o)))
+}
+
+func (l TypedList[T]) SymmetricDifference(o TypedIterable[T]) *TypedList[T] {
+	return typedList[T](l.list.SymmetricDifference(untypedSource(o)))
+}
+
+func (l TypedList[T]) UniqueHash() *TypedList[T] {
+	return typedList[T](l.list.UniqueHash())
+}
+
+func (l TypedList[T]) UnionHash(o TypedIterable[T]) *TypedList[T] {
+	return typedList[T](l.list.UnionHash(untypedSource(o)))
+}
+
+func (l TypedList[T]) IntersectionHash(o TypedIterable[T]) *TypedList[T] {
+	return typedList[T](l.list.IntersectionHash(untypedSource(o)))
+}
+
+func (l TypedList[T]) DifferenceHash(o TypedIterable[T]) *TypedList[T] {
+	return typedList[T](l.list.DifferenceHash(untypedSource(o)))
+}
+
+func (l TypedList[T]) SymmetricDifferenceHash(o TypedIterable[T]) *TypedList[T] {
+	return typedList[T](l.list.SymmetricDifferenceHash(untypedSource(o)))
+}
+
+
+//	A declarative method for building TypedLoops
+func LoopOf[T any](items... T) (c *TypedLoop[T]) {
+	c = NewTypedLoop[T](&chain.Cell{})
+	c.Concatenate(items)
+	return
+}
+
+type TypedLoop[T any] struct {
+	TypedHeader[T]
+	loop		*CycList
+}
+
+func NewTypedLoop[T any](n chain.Node) *TypedLoop[T] {
+	return typedLoop[T](NewCycList(n))
+}
+
+func typedLoop[T any](c *CycList) *TypedLoop[T] {
+	return &TypedLoop[T]{ TypedHeader[T]{ &c.ListHeader }, c }
+}
+
+func (c TypedLoop[T]) Untyped() *CycList {
+	return c.loop
+}
+
+func (c TypedLoop[T]) String() string {
+	return c.loop.String()
+}
+
+func (c TypedLoop[T]) Clone() *TypedLoop[T] {
+	return typedLoop[T](c.loop.Clone())
+}
+
+func (c TypedLoop[T]) Equal(o interface{}) (r bool) {
+	switch o := o.(type) {
+	case *TypedLoop[T]:		r = o != nil && c.loop.Equal(o.loop)
+	case TypedLoop[T]:		r = c.loop.Equal(o.loop)
+	default:				r = c.loop.Equal(o)
+	}
+	return
+}
+
+func (c TypedLoop[T]) At(i int) T {
+	return typed[T](c.loop.At(i))
+}
+
+func (c TypedLoop[T]) Set(i int, v T) {
+	c.loop.Set(i, v)
+}
+
+func (c TypedLoop[T]) Cycling() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range c.loop.Cycling() {
+			if !yield(typed[T](v)) {
+				return
+			}
+		}
+	}
+}
+
+func (c *TypedLoop[T]) Append(v T) {
+	c.loop.Append(v)
+}
+
+func (c *TypedLoop[T]) Concatenate(s []T) {
+	c.loop.Concatenate(s)
+}
+
+func (c *TypedLoop[T]) Reverse() {
+	c.loop.Reverse()
+}
+
+func (c *TypedLoop[T]) SortedInsert(v T, less func(a, b T) bool) int {
+	return c.loop.SortedInsert(v, c.untypedLess(less))
+}
+
+func (c *TypedLoop[T]) Rotate(i int) {
+	c.loop.Rotate(i)
+}
+
+func (c *TypedLoop[T]) Tail() {
+	c.loop.Tail()
+}
+
+func (c *TypedLoop[T]) Expand(i, n int) {
+	c.loop.Expand(i, n)
+}
+
+func (c *TypedLoop[T]) PushFront(v T) {
+	c.loop.PushFront(v)
+}
+
+func (c *TypedLoop[T]) PushBack(v T) {
+	c.loop.PushBack(v)
+}
+
+func (c *TypedLoop[T]) Delete(from, to int) {
+	c.loop.Delete(from, to)
+}
+
+func (c *TypedLoop[T]) Cut(from, to int) *TypedLoop[T] {
+	r := c.loop.Cut(from, to)
+	return typedLoop[T](&r)
+}
+
+func (c *TypedLoop[T]) Insert(i int, v T) {
+	c.loop.Insert(i, v)
+}
+
+func (c *TypedLoop[T]) Absorb(i int, o *TypedLoop[T]) (ok bool) {
+	if o != nil {
+		ok = c.loop.Absorb(i, o.loop)
+	}
+	return
+}
+
+//	Cuts the loop as CycList.Split does, returning the loop itself as r and the remaining elements as s
+func (c *TypedLoop[T]) Split(i, j int) (r, s *TypedLoop[T]) {
+	if _, o := c.loop.Split(i, j); o != nil {
+		r, s = c, typedLoop[T](o)
+	}
+	return
+}
+
+func (c *TypedLoop[T]) Join(o *TypedLoop[T], at int) (ok bool) {
+	if o != nil {
+		ok = c.loop.Join(o.loop, at)
+	}
+	return
+}
+
+func (c *TypedLoop[T]) Step(k int) (r T, ok bool) {
+	var v interface{}
+	if v, ok = c.loop.Step(k); ok {
+		r = typed[T](v)
+	}
+	return
+}
+
+func (c *TypedLoop[T]) RemoveEvery(k int, visit func(T) bool) {
+	c.loop.RemoveEvery(k, c.untypedTest(visit))
+}
+
+//	Iterate over all elements of the loop indefinitely, which only ends when f panics
+func (c TypedLoop[T]) Cycle(f func(T)) {
+	c.loop.Cycle(func(v interface{}) {
+		f(typed[T](v))
+	})
+}
+
+func (c *TypedLoop[T]) Canonicalize(less func(a, b T) bool) int {
+	return c.loop.Canonicalize(c.untypedLess(less))
+}
+
+func (c TypedLoop[T]) EquivalentRotation(o *TypedLoop[T]) bool {
+	return o != nil && c.loop.EquivalentRotation(o.loop)
+}
+
+func (c TypedLoop[T]) RotationOffset(o *TypedLoop[T]) (k int, ok bool) {
+	if o != nil {
+		k, ok = c.loop.RotationOffset(o.loop)
+	}
+	return
+}
+
+func (c TypedLoop[T]) Validate() error {
+	return c.loop.Validate()
+}
+
+func (c *TypedLoop[T]) Debug(on bool) {
+	c.loop.Debug(on)
+}
+
+func (c TypedLoop[T]) DeepEqual(o interface{}) bool {
+	return c.loop.DeepEqual(c.unwrap(o))
+}
+
+//	Determines whether o holds the same elements in the same order, comparing each pair with eq
+func (c TypedLoop[T]) EqualFunc(o interface{}, eq func(a, b T) bool) bool {
+	return c.loop.EqualFunc(c.unwrap(o), c.untypedEqual(eq))
+}
+
+func (c TypedLoop[T]) Map(f func(T) T) *TypedLoop[T] {
+	return typedLoop[T](c.loop.Map(c.untypedMap(f)))
+}
+
+func (c TypedLoop[T]) Filter(f func(T) bool) *TypedLoop[T] {
+	return typedLoop[T](c.loop.Filter(c.untypedTest(f)))
+}
+
+func (c TypedLoop[T]) Reject(f func(T) bool) *TypedLoop[T] {
+	return typedLoop[T](c.loop.Reject(c.untypedTest(f)))
+}
+
+func (c TypedLoop[T]) Unique() *TypedLoop[T] {
+	return typedLoop[T](c.loop.Unique())
+}
+
+func (c TypedLoop[T]) Union(o TypedIterable[T]) *TypedLoop[T] {
+	return typedLoop[T](c.loop.Union(untypedSource(o)))
+}
+
+func (c TypedLoop[T]) Intersection(o TypedIterable[T]) *TypedLoop[T] {
+	return typedLoop[T](c.loop.Intersection(untypedSource(o)))
+}
+
+func (c TypedLoop[T]) Difference(o TypedIterable[T]) *TypedLoop[T] {
+	return typedLoop[T](c.loop.Difference(untypedSource(o)))
+}
+
+func (c TypedLoop[T]) SymmetricDifference(o TypedIterable[T]) *TypedLoop[T] {
+	return typedLoop[T](c.loop.SymmetricDifference(untypedSource(o)))
+}
+
+func (c TypedLoop[T]) UniqueHash() *TypedLoop[T] {
+	return typedLoop[T](c.loop.UniqueHash())
+}
+
+func (c TypedLoop[T]) UnionHash(o TypedIterable[T]) *TypedLoop[T] {
+	return typedLoop[T](c.loop.UnionHash(untypedSource(o)))
+}
+
+func (c TypedLoop[T]) IntersectionHash(o TypedIterable[T]) *TypedLoop[T] {
+	return typedLoop[T](c.loop.IntersectionHash(untypedSource(o)))
+}
+
+func (c TypedLoop[T]) DifferenceHash(o TypedIterable[T]) *TypedLoop[T] {
+	return typedLoop[T](c.loop.DifferenceHash(untypedSource(o)))
+}
+
+func (c TypedLoop[T]) SymmetricDifferenceHash(o TypedIterable[T]) *TypedLoop[T] {
+	return typedLoop[T](c.loop.SymmetricDifferenceHash(untypedSource(o)))
+}
@@ -0,0 +1,486 @@
+package lists
+
+import "encoding/json"
+import "reflect"
+import "testing"
+
+func TestTypedListOf(t *testing.T) {
+	ConfirmFormat := func(l *TypedList[int], x string) {
+		if s := l.String(); s != x {
+			t.Fatalf("'%v' erroneously serialised as '%v'", x, s)
+		}
+	}
+	ConfirmFormat(ListOf[int](), "()")
+	ConfirmFormat(ListOf(1), "(1)")
+	ConfirmFormat(ListOf(3, 2, 1), "(3 2 1)")
+}
+
+func TestTypedListAt(t *testing.T) {
+	ConfirmAt := func(l *TypedList[string], i int, v string) {
+		if x := l.At(i); x != v {
+			t.Fatalf("%v.At(%v) should be %v but is %v", l, i, v, x)
+		}
+	}
+	l := ListOf("a", "b", "c")
+	ConfirmAt(l, 0, "a")
+	ConfirmAt(l, 1, "b")
+	ConfirmAt(l, 2, "c")
+	ConfirmAt(l, 3, "")
+
+	l.Untyped().Set(1, 1)
+	defer func() {
+		if _, ok := recover().(TypeMismatch); !ok {
+			t.Fatalf("At(1) of a mistyped element should panic with a TypeMismatch")
+		}
+	}()
+	l.At(1)
+}
+
+func TestTypedListSet(t *testing.T) {
+	ConfirmSet := func(l *TypedList[int], i int, v int) {
+		l.Set(i, v)
+		if x := l.At(i); x != v {
+			t.Fatalf("%v.Set(%v) should be %v but is %v", l, i, v, x)
+		}
+	}
+	l := ListOf(10, 11, 12)
+	ConfirmSet(l, 0, 20)
+	ConfirmSet(l, 1, 21)
+	ConfirmSet(l, 2, 22)
+}
+
+func TestTypedListEach(t *testing.T) {
+	l := ListOf(0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	count := 0
+	l.Each(func(i int) {
+		if i != count {
+			t.Fatalf("element %v erroneously reported as %v", count, i)
+		}
+		count++
+	})
+	if count != l.Len() {
+		t.Fatalf("list length %v erroneously reported iterations as %v", l.Len(), count)
+	}
+}
+
+func TestTypedListCompact(t *testing.T) {
+	ConfirmCompact := func(l *TypedList[int], r []int) {
+		x := l.Compact()
+		if len(x) != len(r) {
+			t.Fatalf("%v.Compact() should be %v but is %v", l, r, x)
+		}
+		for i, v := range x {
+			if v != r[i] {
+				t.Fatalf("%v.Compact() should be %v but is %v", l, r, x)
+			}
+		}
+	}
+	ConfirmCompact(ListOf[int](), []int{})
+	ConfirmCompact(ListOf(1), []int{ 1 })
+	ConfirmCompact(ListOf(0, 1, 2, 3), []int{ 0, 1, 2, 3 })
+}
+
+func TestTypedListEqual(t *testing.T) {
+	ConfirmEqual := func(l, r *TypedList[int]) {
+		if !l.Equal(r) {
+			t.Fatalf("%v should equal %v", l, r)
+		}
+	}
+	RefuteEqual := func(l, r *TypedList[int]) {
+		if l.Equal(r) {
+			t.Fatalf("%v should not equal %v", l, r)
+		}
+	}
+	ConfirmEqual(ListOf[int](), ListOf[int]())
+	ConfirmEqual(ListOf(0, 1), ListOf(0, 1))
+	RefuteEqual(ListOf(0, 1), ListOf(1, 0))
+	RefuteEqual(ListOf(0, 1), ListOf(0, 1, 2))
+
+	if !ListOf(0, 1).Equal(List(0, 1)) {
+		t.Fatalf("%v should equal %v", ListOf(0, 1), List(0, 1))
+	}
+}
+
+func TestTypedListDelete(t *testing.T) {
+	ConfirmDelete := func(l *TypedList[int], from, to int, r *TypedList[int]) {
+		l.Delete(from, to)
+		if !l.Equal(r) {
+			t.Fatalf("Delete(%v, %v) should be '%v' and not '%v'", from, to, r, l)
+		}
+	}
+	ConfirmDelete(ListOf(0, 1, 2, 3), 0, 0, ListOf(1, 2, 3))
+	ConfirmDelete(ListOf(0, 1, 2, 3), 1, 2, ListOf(0, 3))
+	ConfirmDelete(ListOf(0, 1, 2, 3), 3, 3, ListOf(0, 1, 2))
+}
+
+func TestTypedListCut(t *testing.T) {
+	ConfirmCut := func(l *TypedList[int], from, to int, r1, r2 *TypedList[int]) {
+		x := l.Cut(from, to)
+		switch {
+		case !x.Equal(r1):		t.Fatalf("Cut(%v, %v) cut should be '%v' and not '%v'", from, to, r1, x)
+		case !l.Equal(r2):		t.Fatalf("Cut(%v, %v) remainder should be '%v' and not '%v'", from, to, r2, l)
+		}
+	}
+	ConfirmCut(ListOf(0, 1, 2, 3), 0, 1, ListOf(0, 1), ListOf(2, 3))
+	ConfirmCut(ListOf(0, 1, 2, 3), 1, 2, ListOf(1, 2), ListOf(0, 3))
+	ConfirmCut(ListOf(0, 1, 2, 3), 2, 3, ListOf(2, 3), ListOf(0, 1))
+}
+
+func TestTypedListInsert(t *testing.T) {
+	ConfirmInsert := func(l *TypedList[int], i, v int, r *TypedList[int]) {
+		l.Insert(i, v)
+		if !l.Equal(r) {
+			t.Fatalf("Insert(%v, %v) should be %v but is %v", i, v, r, l)
+		}
+	}
+	ConfirmInsert(ListOf[int](), 0, 1, ListOf(1))
+	ConfirmInsert(ListOf(0, 1), 0, 2, ListOf(2, 0, 1))
+	ConfirmInsert(ListOf(0, 1), 1, 2, ListOf(0, 2, 1))
+	ConfirmInsert(ListOf(0, 1), 2, 2, ListOf(0, 1, 2))
+}
+
+func TestTypedListAbsorb(t *testing.T) {
+	l := ListOf(0, 1, 2, 3)
+	if !l.Absorb(2, ListOf(-2, -1)) {
+		t.Fatalf("Absorb(2, ...) should return true")
+	}
+	if r := ListOf(0, 1, -2, -1, 2, 3); !l.Equal(r) {
+		t.Fatalf("Absorb(2, ...) result should be '%v' and not %v", r, l)
+	}
+	if l.Absorb(0, nil) {
+		t.Fatalf("Absorb(0, nil) should return false")
+	}
+}
+
+func TestTypedListReverse(t *testing.T) {
+	l := ListOf(1, 2, 3)
+	l.Reverse()
+	if r := ListOf(3, 2, 1); !l.Equal(r) {
+		t.Fatalf("'%v' should be '%v'", l, r)
+	}
+}
+
+func TestTypedListClone(t *testing.T) {
+	l := ListOf(0, 1, 2)
+	x := l.Clone()
+	x.Set(0, 10)
+	switch {
+	case !l.Equal(ListOf(0, 1, 2)):		t.Fatalf("clone modified original %v", l)
+	case !x.Equal(ListOf(10, 1, 2)):	t.Fatalf("clone should be %v but is %v", ListOf(10, 1, 2), x)
+	}
+}
+
+func TestTypedLoopOf(t *testing.T) {
+	ConfirmFormat := func(c *TypedLoop[int], x string) {
+		if s := c.String(); s != x {
+			t.Fatalf("'%v' erroneously serialised as '%v'", x, s)
+		}
+	}
+	ConfirmFormat(LoopOf[int](), "(...)")
+	ConfirmFormat(LoopOf(1), "(1 ...)")
+	ConfirmFormat(LoopOf(3, 2, 1), "(3 2 1 ...)")
+}
+
+func TestTypedLoopAt(t *testing.T) {
+	ConfirmAt := func(c *TypedLoop[int], i int, v int) {
+		if x := c.At(i); x != v {
+			t.Fatalf("%v.At(%v) should be %v but is %v", c, i, v, x)
+		}
+	}
+	c := LoopOf(10, 11, 12, 13)
+	ConfirmAt(c, -1, 13)
+	ConfirmAt(c, 0, 10)
+	ConfirmAt(c, 5, 11)
+}
+
+func TestTypedLoopRotate(t *testing.T) {
+	ConfirmRotate := func(c *TypedLoop[int], i int, r *TypedLoop[int]) {
+		c.Rotate(i)
+		if !c.Equal(r) {
+			t.Fatalf("%v should be %v", c, r)
+		}
+	}
+	ConfirmRotate(LoopOf(0, 1, 2, 3), 1, LoopOf(1, 2, 3, 0))
+	ConfirmRotate(LoopOf(0, 1, 2, 3), -1, LoopOf(3, 0, 1, 2))
+}
+
+func TestTypedLoopAppend(t *testing.T) {
+	c := LoopOf(1)
+	c.Append(2)
+	switch {
+	case !c.Equal(LoopOf(1, 2)):	t.Fatalf("%v should be %v", c, LoopOf(1, 2))
+	case c.At(2) != 1:				t.Fatalf("%v should cycle back to its start", c)
+	}
+}
+
+func TestTypedLoopReverse(t *testing.T) {
+	c := LoopOf(1, 2, 3)
+	c.Reverse()
+	if r := LoopOf(3, 2, 1); !c.Equal(r) {
+		t.Fatalf("%v should be %v", c, r)
+	}
+}
+
+func TestTypedListAll(t *testing.T) {
+	l := ListOf("a", "b", "c")
+	r := []string{ "a", "b", "c" }
+	for i, v := range l.All() {
+		if v != r[i] {
+			t.Fatalf("element %v erroneously reported as %v", i, v)
+		}
+	}
+	for i, v := range l.Backward() {
+		if v != r[i] {
+			t.Fatalf("element %v erroneously reported as %v", i, v)
+		}
+	}
+}
+
+func TestTypedLoopCycling(t *testing.T) {
+	c := LoopOf(0, 1, 2)
+	count := 0
+	for v := range c.Cycling() {
+		if v != count % 3 {
+			t.Fatalf("element %v erroneously reported as %v", count, v)
+		}
+		if count++; count == 7 {
+			break
+		}
+	}
+}
+
+//	Every method of LinearList and CycList should have a typed counterpart, apart from those which
+//	deal in nodes, nested lists or encodings and are reached through Untyped()
+func TestTypedMethodSets(t *testing.T) {
+	untyped := map[string]bool{
+		"NewListNode": true, "Cursor": true, "Slice": true,
+		"Flatten": true, "Flattened": true, "FlattenDepth": true, "FlattenFunc": true, "FlatMap": true,
+		"UnmarshalJSON": true, "UseNumberMode": true,
+		"MarshalBinary": true, "UnmarshalBinary": true, "GobEncode": true, "GobDecode": true,
+	}
+	ConfirmMethods := func(l, s interface{}) {
+		lt, st := reflect.TypeOf(l), reflect.TypeOf(s)
+		for i := 0; i < lt.NumMethod(); i++ {
+			if name := lt.Method(i).Name; !untyped[name] {
+				if _, ok := st.MethodByName(name); !ok {
+					t.Errorf("%v lacks %v", st, name)
+				}
+			}
+		}
+	}
+	ConfirmMethods(&LinearList{}, &TypedList[int]{})
+	ConfirmMethods(&CycList{}, &TypedLoop[int]{})
+}
+
+func TestTypedListDeque(t *testing.T) {
+	l := ListOf(2, 3)
+	l.PushFront(1)
+	l.PushBack(4)
+	if r := ListOf(1, 2, 3, 4); !l.Equal(r) {
+		t.Fatalf("%v should be %v", l, r)
+	}
+	ConfirmPop := func(name string, f func() (int, bool), v int, ok bool) {
+		if x, k := f(); x != v || k != ok {
+			t.Fatalf("%v() should be (%v, %v) not (%v, %v)", name, v, ok, x, k)
+		}
+	}
+	ConfirmPop("PeekFront", l.PeekFront, 1, true)
+	ConfirmPop("PeekBack", l.PeekBack, 4, true)
+	ConfirmPop("PopFront", l.PopFront, 1, true)
+	ConfirmPop("PopBack", l.PopBack, 4, true)
+	ConfirmPop("PopBack", l.PopBack, 3, true)
+	ConfirmPop("PopBack", l.PopBack, 2, true)
+	ConfirmPop("PopBack", l.PopBack, 0, false)
+	ConfirmPop("PeekFront", l.PeekFront, 0, false)
+
+	c := LoopOf(2, 3)
+	c.PushFront(1)
+	c.PushBack(4)
+	if r := LoopOf(1, 2, 3, 4); !c.Equal(r) {
+		t.Fatalf("%v should be %v", c, r)
+	}
+	ConfirmPop("PopFront", c.PopFront, 1, true)
+	ConfirmPop("PopBack", c.PopBack, 4, true)
+	if c.At(2) != 2 {
+		t.Fatalf("%v should cycle back to its start", c)
+	}
+}
+
+func TestTypedHeadTailExpand(t *testing.T) {
+	l := ListOf(1, 2, 3)
+	l.Tail()
+	switch {
+	case l.Head() != 2:				t.Fatalf("Head() of %v should be 2", l)
+	case !l.Equal(ListOf(2, 3)):	t.Fatalf("Tail() should leave (2 3) not %v", l)
+	}
+	l.Expand(1, 2)
+	if l.Len() != 4 || l.At(1) != 0 || l.At(2) != 0 || l.At(3) != 3 {
+		t.Fatalf("Expand(1, 2) should give (2 nil nil 3) not %v", l)
+	}
+	if e := l.Validate(); e != nil {
+		t.Fatalf("Validate() failed: %v", e)
+	}
+
+	c := LoopOf(1, 2, 3)
+	c.Tail()
+	switch {
+	case c.Head() != 2:				t.Fatalf("Head() of %v should be 2", c)
+	case c.At(2) != 2:				t.Fatalf("%v should cycle back to its start", c)
+	}
+	c.Expand(2, 1)
+	if c.Len() != 3 || c.At(2) != 0 || c.At(3) != 2 {
+		t.Fatalf("Expand(2, 1) should give (2 3 nil ...) not %v", c)
+	}
+}
+
+func TestTypedLoopEditing(t *testing.T) {
+	c := LoopOf(0, 1, 2, 3)
+	c.Insert(1, 9)
+	if r := LoopOf(0, 9, 1, 2, 3); !c.Equal(r) {
+		t.Fatalf("Insert(1, 9) should give %v not %v", r, c)
+	}
+	c.Delete(1, 1)
+	if r := LoopOf(0, 1, 2, 3); !c.Equal(r) {
+		t.Fatalf("Delete(1, 1) should give %v not %v", r, c)
+	}
+	x := c.Cut(1, 2)
+	switch {
+	case !x.Equal(LoopOf(1, 2)):	t.Fatalf("Cut(1, 2) should cut (1 2 ...) not %v", x)
+	case !c.Equal(LoopOf(0, 3)):	t.Fatalf("Cut(1, 2) should leave (0 3 ...) not %v", c)
+	}
+	if !c.Absorb(1, x) {
+		t.Fatalf("Absorb(1, ...) should return true")
+	}
+	if r := LoopOf(0, 1, 2, 3); !c.Equal(r) {
+		t.Fatalf("Absorb(1, ...) should give %v not %v", r, c)
+	}
+	if c.Absorb(0, nil) {
+		t.Fatalf("Absorb(0, nil) should return false")
+	}
+
+	if e := LoopOf[int]().InsertE(1, 1); e == nil {
+		t.Fatalf("InsertE(1, 1) on an empty loop should fail")
+	}
+	if e := LoopOf[int]().DeleteE(0, 0); e == nil {
+		t.Fatalf("DeleteE(0, 0) on an empty loop should fail")
+	}
+	if x, e := LoopOf(1, 2).CutE(0, 0); e != nil || !x.Equal(LoopOf(1)) {
+		t.Fatalf("CutE(0, 0) should give (1 ...) not %v, %v", x, e)
+	}
+	if e := LoopOf(1).AbsorbE(0, LoopOf(2)); e != nil {
+		t.Fatalf("AbsorbE(0, ...) failed: %v", e)
+	}
+}
+
+func TestTypedLoopRotations(t *testing.T) {
+	c := LoopOf(3, 1, 2)
+	if k, ok := c.RotationOffset(LoopOf(1, 2, 3)); !ok || k != 1 {
+		t.Fatalf("RotationOffset() should be 1 not %v, %v", k, ok)
+	}
+	if !c.EquivalentRotation(LoopOf(2, 3, 1)) || c.EquivalentRotation(LoopOf(2, 1, 3)) || c.EquivalentRotation(nil) {
+		t.Fatalf("EquivalentRotation() gave the wrong result for %v", c)
+	}
+	c.Canonicalize(func(a, b int) bool { return a < b })
+	if r := LoopOf(1, 2, 3); !c.Equal(r) {
+		t.Fatalf("Canonicalize() should give %v not %v", r, c)
+	}
+	if v, ok := c.Step(2); !ok || v != 3 {
+		t.Fatalf("Step(2) should give 3 not %v, %v", v, ok)
+	}
+
+	var removed []int
+	j := LoopOf(1, 2, 3, 4, 5)
+	j.RemoveEvery(2, func(v int) bool {
+		removed = append(removed, v)
+		return true
+	})
+	if r := []int{ 2, 4, 1, 5, 3 }; !reflect.DeepEqual(removed, r) {
+		t.Fatalf("RemoveEvery(2, ...) should remove %v not %v", r, removed)
+	}
+
+	c = LoopOf(0, 1, 2, 3)
+	r, s := c.Split(0, 2)
+	switch {
+	case r != c:					t.Fatalf("Split() should return the loop itself")
+	case !r.Equal(LoopOf(0, 1)):	t.Fatalf("Split(0, 2) should leave (0 1 ...) not %v", r)
+	case !s.Equal(LoopOf(2, 3)):	t.Fatalf("Split(0, 2) should give (2 3 ...) not %v", s)
+	}
+	if !r.Join(s, 1) || !r.Equal(LoopOf(0, 2, 3, 1)) || s.Len() != 0 {
+		t.Fatalf("Join(s, 1) should give (0 2 3 1 ...) not %v", r)
+	}
+}
+
+func TestTypedQueries(t *testing.T) {
+	l := ListOf(1, 2, 3, 4)
+	even := func(v int) bool { return v % 2 == 0 }
+	sum := func(memo, v int) int { return memo + v }
+	switch {
+	case !l.Any(even):								t.Fatalf("Any() should be true")
+	case l.Every(even):								t.Fatalf("Every() should be false")
+	case l.Count(even) != 2:						t.Fatalf("Count() should be 2")
+	case l.FindIndex(even) != 1:					t.Fatalf("FindIndex() should be 1")
+	case l.Reduce(sum) != 10:						t.Fatalf("Reduce() should be 10")
+	case l.FoldLeft(5, sum) != 15:					t.Fatalf("FoldLeft() should be 15")
+	case l.FoldRight(0, func(v, memo int) int { return memo * 10 + v }) != 4321:
+													t.Fatalf("FoldRight() should be 4321")
+	case !l.Map(func(v int) int { return v * v }).Equal(ListOf(1, 4, 9, 16)):
+													t.Fatalf("Map() gave the wrong result")
+	case !l.Filter(even).Equal(ListOf(2, 4)):		t.Fatalf("Filter() gave the wrong result")
+	case !l.Reject(even).Equal(ListOf(1, 3)):		t.Fatalf("Reject() gave the wrong result")
+	case !l.DeepEqual(ListOf(1, 2, 3, 4)):			t.Fatalf("DeepEqual() should be true")
+	case !l.EqualFunc(List(1, 2, 3, 4), func(a, b int) bool { return a == b }):
+													t.Fatalf("EqualFunc() should be true")
+	case l.EqualFunc(List(1, 2, 3, "4"), func(a, b int) bool { return true }):
+													t.Fatalf("EqualFunc() should be false for an element of another type")
+	}
+	if v, ok := l.Find(even); !ok || v != 2 {
+		t.Fatalf("Find() should give 2 not %v, %v", v, ok)
+	}
+	l.FilterInPlace(even)
+	l.MapInPlace(func(v int) int { return v + 1 })
+	if r := ListOf(3, 5); !l.Equal(r) {
+		t.Fatalf("FilterInPlace() and MapInPlace() should give %v not %v", r, l)
+	}
+	c := LoopOf(1, 2, 3, 4)
+	c.RejectInPlace(even)
+	if r := LoopOf(1, 3); !c.Equal(r) {
+		t.Fatalf("RejectInPlace() should give %v not %v", r, c)
+	}
+}
+
+func TestTypedSets(t *testing.T) {
+	l := ListOf(1, 2, 2, 3)
+	o := ListOf(3, 4)
+	ConfirmSet := func(name string, x, r *TypedList[int]) {
+		if !x.Equal(r) {
+			t.Fatalf("%v should be %v not %v", name, r, x)
+		}
+	}
+	ConfirmSet("Unique()", l.Unique(), ListOf(1, 2, 3))
+	ConfirmSet("Union()", l.Union(o), ListOf(1, 2, 3, 4))
+	ConfirmSet("Intersection()", l.Intersection(o), ListOf(3))
+	ConfirmSet("Difference()", l.Difference(o), ListOf(1, 2))
+	ConfirmSet("SymmetricDifference()", l.SymmetricDifference(o), ListOf(1, 2, 4))
+	ConfirmSet("UniqueHash()", l.UniqueHash(), ListOf(1, 2, 3))
+	ConfirmSet("UnionHash()", l.UnionHash(LoopOf(3, 4)), ListOf(1, 2, 3, 4))
+	ConfirmSet("IntersectionHash()", l.IntersectionHash(o), ListOf(3))
+	ConfirmSet("DifferenceHash()", l.DifferenceHash(o), ListOf(1, 2))
+	ConfirmSet("SymmetricDifferenceHash()", l.SymmetricDifferenceHash(o), ListOf(1, 2, 4))
+	ConfirmSet("Union(nil)", l.Union(nil), ListOf(1, 2, 3))
+
+	c := LoopOf(1, 2).Union(ListOf(2, 3))
+	if r := LoopOf(1, 2, 3); !c.Equal(r) {
+		t.Fatalf("Union() should be %v not %v", r, c)
+	}
+}
+
+func TestTypedMarshalJSON(t *testing.T) {
+	ConfirmMarshal := func(v interface{}, r string) {
+		if b, e := json.Marshal(v); e != nil || string(b) != r {
+			t.Fatalf("json.Marshal() should give %v not %s, %v", r, b, e)
+		}
+	}
+	ConfirmMarshal(ListOf(1, 2), "[1,2]")
+	ConfirmMarshal(*ListOf(1, 2), "[1,2]")
+	ConfirmMarshal(LoopOf("a"), `["a"]`)
+}
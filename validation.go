@@ -0,0 +1,194 @@
+package lists
+
+import "fmt"
+import "github.com/feyeleanor/chain"
+
+//	A CycleError reports a chain of nodes which loops back on itself where it should not.
+//	Position is the index of the first node on the cycle and Period the number of nodes in it.
+type CycleError struct {
+	Position	int
+	Period		int
+}
+
+func (e CycleError) Error() string {
+	return fmt.Sprintf("cycle of %v nodes starting at position %v", e.Period, e.Position)
+}
+
+//	A LengthError reports a list whose recorded length differs from the number of nodes reachable from its start
+type LengthError struct {
+	Recorded	int
+	Counted		int
+}
+
+func (e LengthError) Error() string {
+	return fmt.Sprintf("length is %v but %v nodes are reachable", e.Recorded, e.Counted)
+}
+
+//	An EndError reports a list whose end is not the last of its nodes
+type EndError struct {
+	Position	int
+}
+
+func (e EndError) Error() string {
+	return fmt.Sprintf("end is not the node at position %v", e.Position)
+}
+
+//	An OpenLoopError reports a circular list whose nodes do not link back to its start
+type OpenLoopError struct {
+	Counted		int
+}
+
+func (e OpenLoopError) Error() string {
+	return fmt.Sprintf("loop terminates after %v nodes", e.Counted)
+}
+
+//	Uses Brent's algorithm to find the first cycle reachable from a node.
+//	When there is no cycle period is 0 and count is the number of nodes in the chain, otherwise
+//	position is the index of the first node on the cycle and count is position + period.
+func findCycle(start chain.Node, next func(chain.Node) chain.Node) (position, period, count int) {
+	if start == nil {
+		return
+	}
+	power := 1
+	period = 1
+	tortoise, hare := start, next(start)
+	for hare != nil && hare != tortoise {
+		if power == period {
+			tortoise = hare
+			power *= 2
+			period = 0
+		}
+		hare = next(hare)
+		period++
+	}
+
+	if hare == nil {
+		for n := start; n != nil; n = next(n) {
+			count++
+		}
+		return 0, 0, count
+	}
+
+	tortoise, hare = start, start
+	for i := period; i > 0; i-- {
+		hare = next(hare)
+	}
+	for tortoise != hare {
+		tortoise = next(tortoise)
+		hare = next(hare)
+		position++
+	}
+	return position, period, position + period
+}
+
+//	Checks the structure of the list against its header, returning an error describing the first violation found.
+//	A list whose end links back to its start is checked as a loop, any other list as a terminated chain.
+func (l ListHeader) Validate() error {
+	return l.validate(l.end != nil && l.next(l.end) == l.start)
+}
+
+func (l ListHeader) validate(closed bool) error {
+	position, period, count := findCycle(l.start, l.next)
+	switch {
+	case closed && period == 0 && count > 0:		return OpenLoopError{ count }
+	case closed && position > 0:					return CycleError{ position, period }
+	case !closed && period > 0:						return CycleError{ position, period }
+	case count != l.length:							return LengthError{ l.length, count }
+	case count == 0 && l.end != nil:				return EndError{ -1 }
+	case count > 0 && lastNode(l.start, count, l.next) != l.end:
+													return EndError{ count - 1 }
+	}
+	return nil
+}
+
+func lastNode(n chain.Node, count int, next func(chain.Node) chain.Node) chain.Node {
+	for ; count > 1; count-- {
+		n = next(n)
+	}
+	return n
+}
+
+//	Checks that the list is a terminated chain of nodes whose length and end match its header
+func (l LinearList) Validate() error {
+	return l.validate(false)
+}
+
+//	Checks that the list is a single loop of nodes passing through its start whose length and end match its header
+func (c CycList) Validate() error {
+	return c.validate(true)
+}
+
+//	Checks that the list is a terminated chain of nodes whose length and end match its header
+func (l DoubleList) Validate() error {
+	return l.validate(false)
+}
+
+//	Checks that the list is a single loop of nodes passing through its start whose length and end match its header
+func (c DoubleLoop) Validate() error {
+	return c.validate(true)
+}
+
+
+//	The shape against which a list in debug mode is validated
+type debugMode int
+
+const (
+	debugOff debugMode = iota
+	debugLinear
+	debugCyclic
+)
+
+//	Turns debug mode on or off. In debug mode the list is validated after every mutation and any
+//	violation raises a panic, which helps to find the operation responsible for corrupting a list.
+//	The list is expected to keep the shape it has when debug mode is turned on, so an empty list is treated as linear.
+func (l *ListHeader) Debug(on bool) {
+	switch {
+	case !on:										l.setDebug(debugOff)
+	case l.end != nil && l.next(l.end) == l.start:	l.setDebug(debugCyclic)
+	default:										l.setDebug(debugLinear)
+	}
+}
+
+//	Turns debug mode on or off, validating the list as a terminated chain after every mutation
+func (l *LinearList) Debug(on bool) {
+	l.setDebug(debugShape(on, debugLinear))
+}
+
+//	Turns debug mode on or off, validating the list as a loop after every mutation
+func (c *CycList) Debug(on bool) {
+	c.setDebug(debugShape(on, debugCyclic))
+}
+
+//	Turns debug mode on or off, validating the list as a terminated chain after every mutation
+func (l *DoubleList) Debug(on bool) {
+	l.setDebug(debugShape(on, debugLinear))
+}
+
+//	Turns debug mode on or off, validating the list as a loop after every mutation
+func (c *DoubleLoop) Debug(on bool) {
+	c.setDebug(debugShape(on, debugCyclic))
+}
+
+func debugShape(on bool, m debugMode) debugMode {
+	if !on {
+		m = debugOff
+	}
+	return m
+}
+
+func (l *ListHeader) setDebug(m debugMode) {
+	l.debug = m
+	l.check()
+}
+
+//	Validates the list when it is in debug mode
+func (l ListHeader) check() {
+	var e error
+	switch l.debug {
+	case debugLinear:		e = l.validate(false)
+	case debugCyclic:		e = l.validate(true)
+	}
+	if e != nil {
+		panic(e)
+	}
+}
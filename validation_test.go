@@ -0,0 +1,201 @@
+package lists
+
+import "github.com/feyeleanor/chain"
+import "testing"
+
+func TestFindCycle(t *testing.T) {
+	ConfirmCycle := func(l *LinearList, link, to, position, period, count int) {
+		if link > -1 {
+			l.findNode(link).Link(chain.NEXT_NODE, l.findNode(to))
+		}
+		p, q, c := findCycle(l.start, chain.Next)
+		switch {
+		case p != position:		t.Fatalf("%v: cycle position should be %v not %v", l.Compact(), position, p)
+		case q != period:		t.Fatalf("%v: cycle period should be %v not %v", l.Compact(), period, q)
+		case c != count:		t.Fatalf("%v: node count should be %v not %v", l.Compact(), count, c)
+		}
+	}
+	ConfirmCycle(List(), -1, 0, 0, 0, 0)
+	ConfirmCycle(List(0), -1, 0, 0, 0, 1)
+	ConfirmCycle(List(0, 1, 2, 3), -1, 0, 0, 0, 4)
+	ConfirmCycle(List(0), 0, 0, 0, 1, 1)
+	ConfirmCycle(List(0, 1, 2, 3), 3, 0, 0, 4, 4)
+	ConfirmCycle(List(0, 1, 2, 3), 3, 3, 3, 1, 4)
+	ConfirmCycle(List(0, 1, 2, 3, 4, 5, 6), 6, 2, 2, 5, 7)
+}
+
+func TestLinearListValidate(t *testing.T) {
+	ConfirmValid := func(l *LinearList) {
+		if e := l.Validate(); e != nil {
+			t.Fatalf("%v should be valid: %v", l, e)
+		}
+	}
+	RefuteValid := func(l *LinearList, x error) {
+		if e := l.Validate(); e != x {
+			t.Fatalf("%v.Validate() should be %v not %v", l.Compact(), x, e)
+		}
+	}
+	ConfirmValid(List())
+	ConfirmValid(List(0))
+	ConfirmValid(List(0, 1, 2))
+
+	l := List(0, 1, 2)
+	l.Reverse()
+	ConfirmValid(l)
+
+	l = List(0, 1, 2)
+	l.end.Link(chain.NEXT_NODE, l.start)
+	RefuteValid(l, CycleError{ 0, 3 })
+
+	l = List(0, 1, 2, 3)
+	l.end.Link(chain.NEXT_NODE, l.findNode(1))
+	RefuteValid(l, CycleError{ 1, 3 })
+
+	l = List(0, 1, 2)
+	l.length = 2
+	RefuteValid(l, LengthError{ 2, 3 })
+
+	l = List(0, 1, 2)
+	l.end = l.findNode(1)
+	RefuteValid(l, EndError{ 2 })
+
+	l = List()
+	l.end = List(0).start
+	RefuteValid(l, EndError{ -1 })
+
+	if e := Loop(0, 1).ListHeader.Validate(); e != nil {
+		t.Fatalf("a loop header should be valid: %v", e)
+	}
+}
+
+func TestCycListValidate(t *testing.T) {
+	ConfirmValid := func(c *CycList) {
+		if e := c.Validate(); e != nil {
+			t.Fatalf("%v should be valid: %v", c, e)
+		}
+	}
+	RefuteValid := func(c *CycList, x error) {
+		if e := c.Validate(); e != x {
+			t.Fatalf("%v.Validate() should be %v not %v", c.Compact(), x, e)
+		}
+	}
+	ConfirmValid(Loop())
+	ConfirmValid(Loop(0))
+	ConfirmValid(Loop(0, 1, 2))
+
+	c := Loop(0, 1, 2)
+	c.Reverse()
+	c.Rotate(1)
+	c.Tail()
+	ConfirmValid(c)
+
+	c = Loop(0, 1, 2)
+	c.end.Link(chain.NEXT_NODE, nil)
+	RefuteValid(c, OpenLoopError{ 3 })
+
+	c = Loop(0, 1, 2)
+	c.end.Link(chain.NEXT_NODE, c.findNode(1))
+	RefuteValid(c, CycleError{ 1, 2 })
+
+	c = Loop(0, 1, 2)
+	c.length = 4
+	RefuteValid(c, LengthError{ 4, 3 })
+
+	c = Loop(0, 1, 2)
+	c.end = c.start
+	RefuteValid(c, EndError{ 2 })
+}
+
+func TestDebug(t *testing.T) {
+	ConfirmPanic := func(l *LinearList, f func()) {
+		defer func() {
+			if x := recover(); x == nil {
+				t.Fatalf("%v should panic in debug mode", l.Compact())
+			} else if _, ok := x.(error); !ok {
+				t.Fatalf("debug mode should panic with an error not %v", x)
+			}
+		}()
+		f()
+	}
+	l := List(0, 1, 2, 3)
+	l.Debug(true)
+	l.Delete(1, 2)
+	l.Insert(1, 1)
+	l.Absorb(0, List(-1))
+	l.Reverse()
+	l.Sort(intLess)
+	if !l.Equal(List(-1, 0, 1, 3)) {
+		t.Fatalf("debug mode should not alter the list %v", l)
+	}
+
+	l.end.Link(chain.NEXT_NODE, l.start)
+	ConfirmPanic(l, func() { l.Append(4) })
+
+	l = List(0, 1, 2)
+	l.length = 5
+	ConfirmPanic(l, func() { l.Debug(true) })
+
+	l = List(0, 1, 2)
+	l.length = 5
+	l.Append(3)
+
+	c := Loop(0, 1, 2)
+	c.Debug(true)
+	c.Append(3)
+	c.Rotate(2)
+	c.Reverse()
+	c.Tail()
+	c.Sort(intLess)
+	if !c.Equal(Loop(0, 2, 3)) {
+		t.Fatalf("debug mode should not alter the loop %v", c)
+	}
+
+	c = Loop()
+	c.Debug(true)
+	c.Concatenate([]interface{}{ 0, 1 })
+	c.Debug(false)
+	c.end.Link(chain.NEXT_NODE, nil)
+	c.Append(2)
+
+	d := List()
+	d.ListHeader.Debug(true)
+	d.Append(0)
+	if d.debug != debugLinear {
+		t.Fatalf("an empty list should be debugged as linear")
+	}
+	c = Loop(0)
+	if c.ListHeader.Debug(true); c.debug != debugCyclic {
+		t.Fatalf("a closed list should be debugged as cyclic")
+	}
+}
+
+func TestMutationsRemainValid(t *testing.T) {
+	ConfirmValid := func(name string, l interface{ Validate() error }) {
+		if e := l.Validate(); e != nil {
+			t.Fatalf("%v should leave %v valid: %v", name, l, e)
+		}
+	}
+	l := List(0, 1, 2)
+	l.Reverse()
+	ConfirmValid("Reverse()", l)
+
+	l = List(0)
+	l.Tail()
+	ConfirmValid("Tail()", l)
+
+	c := Loop(0, 1, 2)
+	c.Tail()
+	ConfirmValid("Tail()", c)
+
+	l = List()
+	l.Absorb(0, List(0, 1))
+	ConfirmValid("Absorb()", l)
+
+	l = List(0, 1, 2, 3)
+	r := l.Cut(3, 3)
+	ConfirmValid("Cut()", l)
+	ConfirmValid("Cut()", &r)
+	r = l.Cut(1, 2)
+	ConfirmValid("Cut()", l)
+	ConfirmValid("Cut()", &r)
+}
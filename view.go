@@ -0,0 +1,161 @@
+package lists
+
+import "iter"
+import "github.com/feyeleanor/chain"
+
+/*
+	A View is a window onto a contiguous range of elements of a list which shares the list's
+	nodes rather than copying them, so it is cheap to create whatever its length.
+
+	Setting an element of a View sets the element of the underlying list. Any change to the
+	structure of the list, such as inserting, deleting or reordering elements, makes the View
+	stale. A stale View reports a ListModified error from Check(), AtE() and Materialize(),
+	whilst At(), Set(), Each() and its iterators panic with a ListModified error.
+*/
+
+//	ListModified reports the use of a View after the structure of its underlying list has changed
+type ListModified struct{}
+
+func (e ListModified) Error() string {
+	return "lists: list modified since view was taken"
+}
+
+type View struct {
+	list			*ListHeader
+	offset			int
+	length			int
+	modifications	int
+}
+
+//	Returns a View of the elements in the inclusive range from to to.
+//	As with Cut the range is clamped to the bounds of the list, so the View may be empty.
+func (l *ListHeader) Slice(from, to int) (v *View) {
+	l.straighten()
+	v = &View{ list: l, modifications: l.modifications }
+	if l.EnforceBounds(&from, &to) {
+		v.offset = from
+		v.length = to - from + 1
+	}
+	return
+}
+
+//	Returns a View of the elements of the View in the inclusive range from to to, relative to the start of the View
+func (v *View) Slice(from, to int) (r *View) {
+	v.mustCheck()
+	r = &View{ list: v.list, modifications: v.modifications }
+	if from < 0 {
+		from = 0
+	}
+	if to > v.length - 1 {
+		to = v.length - 1
+	}
+	if to >= from {
+		r.offset = v.offset + from
+		r.length = to - from + 1
+	}
+	return
+}
+
+//	Reports whether the View can still be used
+func (v *View) Check() (e error) {
+	if v.list.modifications != v.modifications {
+		e = ListModified{}
+	}
+	return
+}
+
+func (v *View) mustCheck() {
+	if e := v.Check(); e != nil {
+		panic(e)
+	}
+}
+
+func (v *View) Len() int {
+	return v.length
+}
+
+func (v *View) node(i int) (n chain.Node) {
+	if i > -1 && i < v.length {
+		n = v.list.findNode(v.offset + i)
+	}
+	return
+}
+
+//	Returns the value stored at the given offset from the start of the View, or nil if there is no such element
+func (v *View) At(i int) (r interface{}) {
+	v.mustCheck()
+	if n := v.node(i); n != nil {
+		r = n.Content()
+	}
+	return
+}
+
+//	Returns the value stored at the given offset from the start of the View, or an error if there is no such element or the View is stale
+func (v *View) AtE(i int) (r interface{}, e error) {
+	switch e = v.Check(); {
+	case e != nil:
+	case i < 0 || i >= v.length:	e = IndexOutOfRange{ i, v.length }
+	default:						r = v.node(i).Content()
+	}
+	return
+}
+
+//	Sets the value stored at the given offset from the start of the View in the underlying list
+func (v *View) Set(i int, x interface{}) {
+	v.mustCheck()
+	if n := v.node(i); n != nil {
+		n.Set(chain.CURRENT_NODE, x)
+	}
+}
+
+func (v *View) Each(f func(interface{})) {
+	for x := range v.Values() {
+		f(x)
+	}
+}
+
+//	Returns an iterator over the index and value of each element of the View, with indices relative to the start of the View
+func (v *View) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		v.mustCheck()
+		n := v.node(0)
+		for i := 0; i < v.length; i++ {
+			if !yield(i, n.Content()) {
+				return
+			}
+			v.mustCheck()
+			n = chain.Next(n)
+		}
+	}
+}
+
+//	Returns an iterator over the value of each element of the View
+func (v *View) Values() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, x := range v.All() {
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+//	Copies the elements of the View into a new LinearList using the node type of the underlying list
+func (v *View) Materialize() (r *LinearList, e error) {
+	if e = v.Check(); e == nil {
+		r = &LinearList{ *v.list.newHeader() }
+		for x := range v.Values() {
+			r.appendValue(x)
+		}
+	}
+	return
+}
+
+func (v *View) String() (t string) {
+	if l, e := v.Materialize(); e == nil {
+		t = l.String()
+	} else {
+		t = e.Error()
+	}
+	return
+}
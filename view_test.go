@@ -0,0 +1,106 @@
+package lists
+
+import "testing"
+
+func TestSlice(t *testing.T) {
+	ConfirmSlice := func(l *LinearList, from, to int, r *LinearList) {
+		v := l.Slice(from, to)
+		switch m, e := v.Materialize(); {
+		case e != nil:				t.Fatalf("%v.Slice(%v, %v) should be usable: %v", l, from, to, e)
+		case !m.Equal(r):			t.Fatalf("%v.Slice(%v, %v) should be %v but is %v", l, from, to, r, m)
+		case v.Len() != r.Len():	t.Fatalf("%v.Slice(%v, %v) length should be %v not %v", l, from, to, r.Len(), v.Len())
+		}
+	}
+	l := List(0, 1, 2, 3, 4)
+	ConfirmSlice(l, 0, 4, l)
+	ConfirmSlice(l, 1, 3, List(1, 2, 3))
+	ConfirmSlice(l, 3, 3, List(3))
+	ConfirmSlice(l, -2, 1, List(0, 1))
+	ConfirmSlice(l, 3, 9, List(3, 4))
+	ConfirmSlice(l, 3, 2, List())
+	ConfirmSlice(List(), 0, 0, List())
+	ConfirmSlice(&LinearList{ Loop(0, 1, 2).ListHeader }, 1, 2, List(1, 2))
+}
+
+func TestViewAccess(t *testing.T) {
+	l := List(0, 1, 2, 3, 4)
+	v := l.Slice(1, 3)
+	switch {
+	case v.At(0) != 1:			t.Fatalf("At(0) should be 1 not %v", v.At(0))
+	case v.At(2) != 3:			t.Fatalf("At(2) should be 3 not %v", v.At(2))
+	case v.At(3) != nil:		t.Fatalf("At(3) should be nil not %v", v.At(3))
+	case v.String() != "(1 2 3)":	t.Fatalf("String() should be (1 2 3) not %v", v)
+	}
+	if _, e := v.AtE(3); e != (IndexOutOfRange{ 3, 3 }) {
+		t.Fatalf("AtE(3) should fail not %v", e)
+	}
+
+	sum := 0
+	v.Each(func(x interface{}) { sum += x.(int) })
+	if sum != 6 {
+		t.Fatalf("Each() should visit 1, 2 and 3 not total %v", sum)
+	}
+	for i, x := range v.All() {
+		if x != i + 1 {
+			t.Fatalf("All() should yield %v at %v not %v", i + 1, i, x)
+		}
+	}
+
+	v.Set(1, 20)
+	if l.At(2) != 20 {
+		t.Fatalf("Set() should write through to %v", l)
+	}
+	if e := v.Check(); e != nil {
+		t.Fatalf("Set() should not make the view stale: %v", e)
+	}
+
+	w := v.Slice(1, 5)
+	if m, _ := w.Materialize(); !m.Equal(List(20, 3)) {
+		t.Fatalf("Slice(1, 5) should be (20 3) not %v", m)
+	}
+
+	var s Sequence = v
+	if s.Len() != 3 {
+		t.Fatalf("a View should be a Sequence")
+	}
+}
+
+func TestStaleView(t *testing.T) {
+	ConfirmStale := func(name string, mutate func(l *LinearList)) {
+		l := List(0, 1, 2, 3)
+		v := l.Slice(1, 2)
+		mutate(l)
+		if e := v.Check(); e != (ListModified{}) {
+			t.Fatalf("%v should make the view stale not %v", name, e)
+		}
+		if _, e := v.Materialize(); e != (ListModified{}) {
+			t.Fatalf("Materialize() after %v should fail not %v", name, e)
+		}
+		if _, e := v.AtE(0); e != (ListModified{}) {
+			t.Fatalf("AtE() after %v should fail not %v", name, e)
+		}
+		defer func() {
+			if x := recover(); x != (ListModified{}) {
+				t.Fatalf("At() after %v should panic with ListModified not %v", name, x)
+			}
+		}()
+		v.At(0)
+	}
+	ConfirmStale("Append()", func(l *LinearList) { l.Append(4) })
+	ConfirmStale("Insert()", func(l *LinearList) { l.Insert(1, 4) })
+	ConfirmStale("Delete()", func(l *LinearList) { l.Delete(0, 0) })
+	ConfirmStale("Reverse()", func(l *LinearList) { l.Reverse() })
+	ConfirmStale("Sort()", func(l *LinearList) { l.Sort(intLess) })
+	ConfirmStale("Cursor.Remove()", func(l *LinearList) { l.Cursor(0).Remove() })
+
+	l := List(0, 1, 2)
+	v := l.Slice(0, 2)
+	defer func() {
+		if x := recover(); x != (ListModified{}) {
+			t.Fatalf("iterating a view whilst its list changes should panic not %v", x)
+		}
+	}()
+	for range v.Values() {
+		l.Append(3)
+	}
+}
@@ -0,0 +1,135 @@
+package lists
+
+import "github.com/feyeleanor/chain"
+
+/*
+	Zip and its relatives combine parallel Sequences element by element.
+
+	The result is as long as the shortest of the finite Sequences, whilst loops such as
+	CycList and DoubleLoop repeat from their start for as long as necessary. When every
+	Sequence is a loop the result is as long as the shortest loop, and an empty loop always
+	gives an empty result as there is nothing to repeat.
+*/
+
+type zipSource struct {
+	seq			Sequence
+	list		*ListHeader
+	node		chain.Node
+	i			int
+}
+
+func newZipSource(s Sequence) (z *zipSource) {
+	z = &zipSource{ seq: s }
+	if z.list = headerOf(s); z.list != nil {
+		z.node = z.list.start
+	}
+	return
+}
+
+//	Returns the next element of the Sequence, returning to its start after the last element
+func (z *zipSource) next() (v interface{}) {
+	if z.list != nil {
+		v = z.node.Content()
+		if z.i++; z.i % z.list.length == 0 {
+			z.node = z.list.start
+		} else {
+			z.node = z.list.next(z.node)
+		}
+	} else {
+		v = z.seq.At(z.i % z.seq.Len())
+		z.i++
+	}
+	return
+}
+
+//	Determines how many tuples the Sequences produce when zipped together
+func zipLength(lists []Sequence) (n int) {
+	finite, loop := -1, -1
+	for _, s := range lists {
+		switch l := s.Len(); {
+		case !isLoop(s):				if finite == -1 || l < finite {
+											finite = l
+										}
+		case loop == -1 || l < loop:	loop = l
+		}
+	}
+	switch {
+	case loop == 0:		n = 0
+	case finite > -1:	n = finite
+	case loop > -1:		n = loop
+	}
+	return
+}
+
+func zipSources(lists []Sequence) (n int, s []*zipSource) {
+	if n = zipLength(lists); n > 0 {
+		s = make([]*zipSource, len(lists))
+		for i, l := range lists {
+			s[i] = newZipSource(l)
+		}
+	}
+	return
+}
+
+//	Returns a list holding the result of applying f to the corresponding elements of each of the Sequences.
+//	Each call to f receives a fresh slice, so f may keep its arguments.
+func ZipWith(f func(...interface{}) interface{}, lists... Sequence) (r *LinearList) {
+	r = List()
+	n, s := zipSources(lists)
+	for ; n > 0; n-- {
+		tuple := make([]interface{}, len(s))
+		for i, z := range s {
+			tuple[i] = z.next()
+		}
+		r.appendValue(f(tuple...))
+	}
+	return
+}
+
+//	Returns a list of lists, each holding the corresponding elements of each of the Sequences
+func Zip(lists... Sequence) *LinearList {
+	return ZipWith(func(v... interface{}) interface{} {
+		return List(v...)
+	}, lists...)
+}
+
+//	Returns a list holding the elements of the Sequences taken in turn from each, in the order that Zip would group them
+func Interleave(lists... Sequence) (r *LinearList) {
+	r = List()
+	n, s := zipSources(lists)
+	for ; n > 0; n-- {
+		for _, z := range s {
+			r.appendValue(z.next())
+		}
+	}
+	return
+}
+
+//	Returns the elements of a Sequence as a slice of Sequences, treating any element which is not a Sequence as a list of one
+func rows(l Sequence) (r []Sequence) {
+	r = make([]Sequence, 0, l.Len())
+	l.Each(func(v interface{}) {
+		if s, ok := v.(Sequence); ok {
+			r = append(r, s)
+		} else {
+			r = append(r, List(v))
+		}
+	})
+	return
+}
+
+//	Returns a list of lists in which the i-th list holds the i-th element of each of the nested Sequences of l.
+//	Nested loops repeat as they do for Zip.
+func Transpose(l Sequence) *LinearList {
+	return Zip(rows(l)...)
+}
+
+//	Reverses Zip, returning a list for each position within the nested Sequences of l
+func Unzip(l Sequence) (r []*LinearList) {
+	t := Transpose(l)
+	r = make([]*LinearList, 0, t.length)
+	for v := range t.Values() {
+		r = append(r, v.(*LinearList))
+	}
+	return
+}
@@ -0,0 +1,103 @@
+package lists
+
+import "testing"
+
+func TestZip(t *testing.T) {
+	ConfirmZip := func(r *LinearList, lists... Sequence) {
+		if x := Zip(lists...); !x.Equal(r) {
+			t.Fatalf("Zip(%v) should be %v but is %v", lists, r, x)
+		}
+	}
+	ConfirmZip(List())
+	ConfirmZip(List(), List())
+	ConfirmZip(List(List(0), List(1)), List(0, 1))
+	ConfirmZip(List(List(0, "a"), List(1, "b")), List(0, 1), List("a", "b"))
+	ConfirmZip(List(List(0, "a"), List(1, "b")), List(0, 1, 2), List("a", "b"))
+	ConfirmZip(List(), List(0, 1, 2), List())
+	ConfirmZip(List(List(0, "a", true)), List(0, 1), List("a"), List(true, false))
+	ConfirmZip(List(List(0, "a")), List(0, 1), List("a").Slice(0, 0))
+}
+
+func TestZipLoop(t *testing.T) {
+	ConfirmZip := func(r *LinearList, lists... Sequence) {
+		if x := Zip(lists...); !x.Equal(r) {
+			t.Fatalf("Zip(%v) should be %v but is %v", lists, r, x)
+		}
+	}
+	labels := Loop("odd", "even")
+	ConfirmZip(List(List("odd", 1), List("even", 2), List("odd", 3)), labels, List(1, 2, 3))
+	ConfirmZip(List(List(1, "odd"), List(2, "even"), List(3, "odd"), List(4, "even"), List(5, "odd")), List(1, 2, 3, 4, 5), labels)
+	ConfirmZip(List(), labels, List())
+	ConfirmZip(List(), Loop(), List(1, 2))
+	ConfirmZip(List(List("odd", 0), List("even", 1)), labels, Loop(0, 1, 2))
+
+	d := NewDoubleLoop()
+	d.Concatenate([]interface{}{ 0, 1 })
+	ConfirmZip(List(List(0, "a"), List(1, "b"), List(0, "c")), d, List("a", "b", "c"))
+}
+
+func TestZipWith(t *testing.T) {
+	sum := func(v... interface{}) interface{} {
+		r := 0
+		for _, x := range v {
+			r += x.(int)
+		}
+		return r
+	}
+	if x := ZipWith(sum, List(1, 2, 3), List(10, 20, 30), Loop(100)); !x.Equal(List(111, 122, 133)) {
+		t.Fatalf("ZipWith() should be (111 122 133) not %v", x)
+	}
+
+	keep := func(v... interface{}) interface{} {
+		return v
+	}
+	x := ZipWith(keep, List(1, 2, 3), List("a", "b", "c"))
+	for i, r := range [][]interface{}{ { 1, "a" }, { 2, "b" }, { 3, "c" } } {
+		if v := x.At(i).([]interface{}); v[0] != r[0] || v[1] != r[1] {
+			t.Fatalf("ZipWith() should pass each row in its own slice, giving %v at %v not %v", r, i, v)
+		}
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	ConfirmInterleave := func(r *LinearList, lists... Sequence) {
+		if x := Interleave(lists...); !x.Equal(r) {
+			t.Fatalf("Interleave(%v) should be %v but is %v", lists, r, x)
+		}
+	}
+	ConfirmInterleave(List())
+	ConfirmInterleave(List(0, 1, 2), List(0, 1, 2))
+	ConfirmInterleave(List(0, "a", 1, "b"), List(0, 1), List("a", "b", "c"))
+	ConfirmInterleave(List(0, ",", 1, ",", 2, ","), List(0, 1, 2), Loop(","))
+}
+
+func TestTranspose(t *testing.T) {
+	ConfirmTranspose := func(l, r *LinearList) {
+		if x := Transpose(l); !x.Equal(r) {
+			t.Fatalf("Transpose(%v) should be %v but is %v", l, r, x)
+		}
+	}
+	ConfirmTranspose(List(), List())
+	ConfirmTranspose(List(List(0, 1, 2)), List(List(0), List(1), List(2)))
+	ConfirmTranspose(List(List(0, 1, 2), List(3, 4, 5)), List(List(0, 3), List(1, 4), List(2, 5)))
+	ConfirmTranspose(List(List(0, 1), List(3, 4, 5)), List(List(0, 3), List(1, 4)))
+	ConfirmTranspose(List(List(0, 1), 9), List(List(0, 9)))
+	ConfirmTranspose(List(List(0, 1, 2), Loop(9)), List(List(0, 9), List(1, 9), List(2, 9)))
+
+	m := List(List(0, 1, 2), List(3, 4, 5))
+	if x := Transpose(Transpose(m)); !x.Equal(m) {
+		t.Fatalf("Transpose() twice should restore %v not %v", m, x)
+	}
+}
+
+func TestUnzip(t *testing.T) {
+	a, b := List(0, 1, 2), List("a", "b", "c")
+	switch u := Unzip(Zip(a, b)); {
+	case len(u) != 2:		t.Fatalf("Unzip() should return 2 lists not %v", len(u))
+	case !u[0].Equal(a):	t.Fatalf("Unzip() should return %v not %v", a, u[0])
+	case !u[1].Equal(b):	t.Fatalf("Unzip() should return %v not %v", b, u[1])
+	}
+	if u := Unzip(List()); len(u) != 0 {
+		t.Fatalf("Unzip() of an empty list should be empty not %v", u)
+	}
+}